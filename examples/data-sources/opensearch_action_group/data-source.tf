@@ -0,0 +1,12 @@
+data "opensearch_action_group" "read" {
+  action_group_name = "read"
+}
+
+resource "opensearch_role" "reader" {
+  role_name = "logs_reader"
+
+  index_permissions {
+    index_patterns  = ["logs-*"]
+    allowed_actions = data.opensearch_action_group.read.allowed_actions
+  }
+}
@@ -0,0 +1,5 @@
+data "opensearch_action_groups" "all" {}
+
+output "custom_action_groups" {
+  value = [for group in data.opensearch_action_groups.all.action_groups : group.action_group_name if !group.static]
+}
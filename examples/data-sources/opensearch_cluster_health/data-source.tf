@@ -0,0 +1,12 @@
+# Wait for the restored indices to be allocated before managing them
+data "opensearch_cluster_health" "restored" {
+  index           = "logs-*"
+  wait_for_status = "green"
+  timeout         = "5m"
+}
+
+resource "opensearch_index" "this" {
+  name = "logs-summary"
+
+  depends_on = [data.opensearch_cluster_health.restored]
+}
@@ -0,0 +1,8 @@
+data "opensearch_cluster_info" "this" {}
+
+# Only register the model if the ML Commons plugin is installed
+resource "opensearch_ml_model_group" "this" {
+  count = contains(data.opensearch_cluster_info.this.plugins, "opensearch-ml") ? 1 : 0
+
+  name = "my_model_group"
+}
@@ -0,0 +1,7 @@
+data "opensearch_component_template" "ecs_mappings" {
+  name = "ecs_mappings"
+}
+
+output "ecs_fields" {
+  value = keys(jsondecode(data.opensearch_component_template.ecs_mappings.body).template.mappings.properties)
+}
@@ -0,0 +1,12 @@
+# A template managed by another team, extended with a higher priority
+data "opensearch_composable_index_template" "logs" {
+  name = "logs"
+}
+
+resource "opensearch_composable_index_template" "audit_logs" {
+  name = "audit_logs"
+  body = jsonencode(merge(jsondecode(data.opensearch_composable_index_template.logs.body), {
+    index_patterns = ["logs-audit-*"]
+    priority       = 200
+  }))
+}
@@ -0,0 +1,12 @@
+data "opensearch_dashboard_tenant" "global" {
+  tenant_name = "global_tenant"
+}
+
+resource "opensearch_role" "global_reader" {
+  role_name = "global_reader"
+
+  tenant_permissions {
+    tenant_patterns = [data.opensearch_dashboard_tenant.global.tenant_name]
+    allowed_actions = ["kibana_all_read"]
+  }
+}
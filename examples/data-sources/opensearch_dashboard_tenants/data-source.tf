@@ -0,0 +1,7 @@
+data "opensearch_dashboard_tenants" "teams" {
+  name_regex = "^team-"
+}
+
+output "team_tenant_indices" {
+  value = { for tenant in data.opensearch_dashboard_tenants.teams.tenants : tenant.tenant_name => tenant.index }
+}
@@ -0,0 +1,7 @@
+data "opensearch_index_template" "logs" {
+  name = "logs"
+}
+
+output "logs_index_patterns" {
+  value = jsondecode(data.opensearch_index_template.logs.body).index_patterns
+}
@@ -0,0 +1,10 @@
+# Indices rolled over by ISM, which aren't managed by Terraform
+data "opensearch_indices" "logs" {
+  index    = "logs-*"
+  settings = ["index.plugins.index_state_management.*"]
+}
+
+resource "opensearch_ism_policy_mapping" "logs" {
+  policy_id = "logs_policy"
+  indexes   = join(",", data.opensearch_indices.logs.names)
+}
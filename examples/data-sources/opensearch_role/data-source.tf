@@ -0,0 +1,9 @@
+# A built-in role, which can't be managed by Terraform
+data "opensearch_role" "all_access" {
+  role_name = "all_access"
+}
+
+resource "opensearch_roles_mapping" "all_access" {
+  role_name     = data.opensearch_role.all_access.role_name
+  backend_roles = ["admins"]
+}
@@ -0,0 +1,7 @@
+data "opensearch_roles" "dashboards" {
+  name_regex = "^kibana_"
+}
+
+output "reserved_dashboards_roles" {
+  value = [for role in data.opensearch_roles.dashboards.roles : role.role_name if role.reserved]
+}
@@ -0,0 +1,7 @@
+data "opensearch_roles_mapping" "all_access" {
+  role_name = "all_access"
+}
+
+output "all_access_users" {
+  value = data.opensearch_roles_mapping.all_access.users
+}
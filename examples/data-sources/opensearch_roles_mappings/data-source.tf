@@ -0,0 +1,5 @@
+data "opensearch_roles_mappings" "all" {}
+
+output "roles_of_admins" {
+  value = [for mapping in data.opensearch_roles_mappings.all.roles_mappings : mapping.role_name if contains(mapping.backend_roles, "admins")]
+}
@@ -0,0 +1,12 @@
+# The settings and mappings the next rolled over index will get
+data "opensearch_simulate_index" "logs" {
+  name = "logs-000002"
+}
+
+output "logs_shards" {
+  value = data.opensearch_simulate_index.logs.settings["index.number_of_shards"]
+}
+
+output "logs_overlapping_templates" {
+  value = data.opensearch_simulate_index.logs.overlapping[*].name
+}
@@ -0,0 +1,7 @@
+data "opensearch_user" "admin" {
+  username = "admin"
+}
+
+output "admin_backend_roles" {
+  value = data.opensearch_user.admin.backend_roles
+}
@@ -0,0 +1,8 @@
+data "opensearch_users" "service_accounts" {
+  name_regex = "^svc-"
+}
+
+resource "opensearch_roles_mapping" "writer" {
+  role_name = "writer"
+  users     = data.opensearch_users.service_accounts.names
+}
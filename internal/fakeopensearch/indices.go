@@ -0,0 +1,1009 @@
+package fakeopensearch
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type index struct {
+	// settings flattened to keys prefixed with `index.`, with string values
+	settings map[string]interface{}
+	mappings map[string]interface{}
+	aliases  map[string]map[string]interface{}
+	docs     map[string]*document
+	// ID of the ISM policy managing the index, if any
+	ismPolicyID string
+	closed      bool
+}
+
+// document is an object stored with a version, e.g. a document of an index
+// or a policy of a plugin.
+type document struct {
+	id          string
+	source      map[string]interface{}
+	version     int
+	seqNo       int
+	primaryTerm int
+}
+
+// save stores source as the next version of doc, or of a new document if doc
+// is nil.
+func (s *Server) save(doc *document, id string, source map[string]interface{}) *document {
+	if doc == nil {
+		doc = &document{id: id, primaryTerm: 1}
+	}
+	s.seqNo++
+	doc.source = source
+	doc.version++
+	doc.seqNo = s.seqNo
+	return doc
+}
+
+// checkVersion writes a version conflict and returns false if the update of
+// doc is conditional on another sequence number or primary term.
+func checkVersion(w http.ResponseWriter, r *request, doc *document) bool {
+	query := r.URL.Query()
+	seqNo, primaryTerm := query.Get("if_seq_no"), query.Get("if_primary_term")
+	if seqNo == "" && primaryTerm == "" {
+		return true
+	}
+	if doc == nil || seqNo != strconv.Itoa(doc.seqNo) || primaryTerm != strconv.Itoa(doc.primaryTerm) {
+		current := "document does not exist"
+		if doc != nil {
+			current = fmt.Sprintf("current document has seqNo [%d] and primary term [%d]", doc.seqNo, doc.primaryTerm)
+		}
+		writeError(w, http.StatusConflict, "version_conflict_engine_exception",
+			fmt.Sprintf("[%s]: version conflict, required seqNo [%s], primary term [%s]. %s", r.arg(len(r.segments)-1), seqNo, primaryTerm, current))
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *request) {
+	switch r.arg(1) {
+	case "":
+		switch r.Method {
+		case http.MethodHead:
+			if names, _ := s.resolveIndices(r.arg(0)); len(names) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			s.getIndex(w, r)
+		case http.MethodPut:
+			s.createIndex(w, r)
+		case http.MethodDelete:
+			s.deleteIndex(w, r)
+		default:
+			writeUnsupported(w, r)
+		}
+	case "_settings":
+		s.handleIndexSettings(w, r)
+	case "_mapping":
+		s.handleMapping(w, r)
+	case "_count":
+		names, ok := s.mustResolveIndices(w, r.arg(0))
+		if !ok {
+			return
+		}
+		count := 0
+		for _, name := range names {
+			count += len(s.indices[name].docs)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"count":   count,
+			"_shards": map[string]interface{}{"total": len(names), "successful": len(names), "skipped": 0, "failed": 0},
+		})
+	case "_alias", "_aliases":
+		s.handleAlias(w, r)
+	case "_doc":
+		s.handleDocument(w, r)
+	case "_close", "_open":
+		s.openIndex(w, r, r.arg(1) == "_open")
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// openIndex opens or closes the indices of the path. Closed indices keep
+// their settings but have no stats.
+func (s *Server) openIndex(w http.ResponseWriter, r *request, open bool) {
+	if r.Method != http.MethodPost {
+		writeUnsupported(w, r)
+		return
+	}
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+	indices := map[string]interface{}{}
+	for _, name := range names {
+		s.indices[name].closed = !open
+		indices[name] = map[string]interface{}{"closed": !open}
+	}
+	res := map[string]interface{}{"acknowledged": true, "shards_acknowledged": true}
+	if !open {
+		res["indices"] = indices
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// resolveIndices returns the names of the indices matched by expression, a
+// comma separated list of index names, aliases or wildcards, and the first
+// name or alias which doesn't exist.
+func (s *Server) resolveIndices(expression string) (names []string, missing string) {
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, part := range strings.Split(expression, ",") {
+		found := false
+		for name, idx := range s.indices {
+			if matchPattern(part, name) || part == "_all" {
+				add(name)
+				found = true
+				continue
+			}
+			for alias := range idx.aliases {
+				if matchPattern(part, alias) {
+					add(name)
+					found = true
+				}
+			}
+		}
+		if !found && !strings.Contains(part, "*") && part != "_all" && missing == "" {
+			missing = part
+		}
+	}
+	sort.Strings(names)
+	return names, missing
+}
+
+// mustResolveIndices resolves expression, and writes an error if it doesn't
+// match an existing index.
+func (s *Server) mustResolveIndices(w http.ResponseWriter, expression string) ([]string, bool) {
+	names, missing := s.resolveIndices(expression)
+	if missing != "" {
+		writeIndexNotFound(w, missing)
+		return nil, false
+	}
+	return names, true
+}
+
+func writeIndexNotFound(w http.ResponseWriter, name string) {
+	writeError(w, http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", name))
+}
+
+func (s *Server) getIndex(w http.ResponseWriter, r *request) {
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+	res := map[string]interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		res[name] = map[string]interface{}{
+			"aliases":  idx.aliases,
+			"mappings": idx.mappings,
+			"settings": nestSettings(idx.settings),
+		}
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) createIndex(w http.ResponseWriter, r *request) {
+	providedName := r.arg(0)
+	name := resolveDateMath(providedName)
+	if _, ok := s.indices[name]; ok {
+		writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", name))
+		return
+	}
+
+	var body struct {
+		Settings map[string]interface{}            `json:"settings"`
+		Mappings map[string]interface{}            `json:"mappings"`
+		Aliases  map[string]map[string]interface{} `json:"aliases"`
+	}
+	if len(r.body) > 0 && !r.decode(w, &body) {
+		return
+	}
+
+	idx := s.newIndex(providedName)
+	for key, value := range indexSettings(body.Settings) {
+		if value != nil {
+			idx.settings[key] = value
+		}
+	}
+	if body.Mappings != nil {
+		idx.mappings = body.Mappings
+	}
+	for alias, config := range body.Aliases {
+		if config == nil {
+			config = map[string]interface{}{}
+		}
+		idx.aliases[alias] = config
+	}
+	s.indices[name] = idx
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"acknowledged":        true,
+		"shards_acknowledged": true,
+		"index":               name,
+	})
+}
+
+func (s *Server) newIndex(providedName string) *index {
+	return &index{
+		settings: map[string]interface{}{
+			"index.number_of_shards":   "1",
+			"index.number_of_replicas": "1",
+			"index.provided_name":      providedName,
+			"index.creation_date":      strconv.FormatInt(time.Now().UnixMilli(), 10),
+			"index.uuid":               s.newID(),
+			"index.version.created":    "136387927",
+		},
+		mappings: map[string]interface{}{},
+		aliases:  map[string]map[string]interface{}{},
+		docs:     map[string]*document{},
+	}
+}
+
+func (s *Server) deleteIndex(w http.ResponseWriter, r *request) {
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+	for _, name := range names {
+		delete(s.indices, name)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+}
+
+func (s *Server) handleIndexSettings(w http.ResponseWriter, r *request) {
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		flat := r.URL.Query().Get("flat_settings") == "true"
+		res := map[string]interface{}{}
+		for _, name := range names {
+			selected := s.indices[name].settings
+			if r.arg(2) != "" {
+				selected = map[string]interface{}{}
+				for key, value := range s.indices[name].settings {
+					for _, pattern := range strings.Split(r.arg(2), ",") {
+						if matchPattern(pattern, key) {
+							selected[key] = value
+						}
+					}
+				}
+			}
+			var settings interface{} = selected
+			if !flat {
+				settings = nestSettings(selected)
+			}
+			res[name] = map[string]interface{}{"settings": settings}
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut:
+		var body map[string]interface{}
+		if !r.decode(w, &body) {
+			return
+		}
+		if settings, ok := body["settings"].(map[string]interface{}); ok {
+			body = settings
+		}
+		for _, name := range names {
+			for key, value := range indexSettings(body) {
+				if value == nil {
+					delete(s.indices[name].settings, key)
+				} else {
+					s.indices[name].settings[key] = value
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleMapping(w http.ResponseWriter, r *request) {
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		res := map[string]interface{}{}
+		for _, name := range names {
+			res[name] = map[string]interface{}{"mappings": s.indices[name].mappings}
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut, http.MethodPost:
+		var body map[string]interface{}
+		if !r.decode(w, &body) {
+			return
+		}
+		for _, name := range names {
+			mergeMaps(s.indices[name].mappings, body)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleAlias(w http.ResponseWriter, r *request) {
+	names, ok := s.mustResolveIndices(w, r.arg(0))
+	if !ok {
+		return
+	}
+	alias := r.arg(2)
+
+	switch r.Method {
+	case http.MethodGet:
+		res := map[string]interface{}{}
+		for _, name := range names {
+			aliases := map[string]interface{}{}
+			for a, config := range s.indices[name].aliases {
+				if alias == "" || matchPattern(alias, a) {
+					aliases[a] = config
+				}
+			}
+			// Without an alias name, indices without aliases are listed too.
+			if len(aliases) > 0 || alias == "" {
+				res[name] = map[string]interface{}{"aliases": aliases}
+			}
+		}
+		if len(res) == 0 && alias != "" {
+			writeError(w, http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", alias))
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut, http.MethodPost:
+		config := map[string]interface{}{}
+		if len(r.body) > 0 && !r.decode(w, &config) {
+			return
+		}
+		for _, name := range names {
+			s.indices[name].aliases[alias] = config
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		removed := false
+		for _, name := range names {
+			for a := range s.indices[name].aliases {
+				if matchPattern(alias, a) {
+					delete(s.indices[name].aliases, a)
+					removed = true
+				}
+			}
+		}
+		if !removed {
+			writeError(w, http.StatusNotFound, "aliases_not_found_exception", fmt.Sprintf("aliases [%s] missing", alias))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// catIndices lists the indices matched by the path, or all of them, with
+// their stats, which are null for closed indices.
+func (s *Server) catIndices(w http.ResponseWriter, r *request) {
+	names := sortedKeys(s.indices)
+	if r.arg(2) != "" {
+		var ok bool
+		if names, ok = s.mustResolveIndices(w, r.arg(2)); !ok {
+			return
+		}
+	}
+	rows := []map[string]interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		shards, _ := strconv.Atoi(fmt.Sprint(idx.settings["index.number_of_shards"]))
+		replicas, _ := strconv.Atoi(fmt.Sprint(idx.settings["index.number_of_replicas"]))
+		health := "green"
+		if replicas > 0 {
+			health = "yellow"
+		}
+		row := map[string]interface{}{
+			"health":        health,
+			"status":        "open",
+			"index":         name,
+			"uuid":          fmt.Sprint(idx.settings["index.uuid"]),
+			"pri":           strconv.Itoa(shards),
+			"rep":           strconv.Itoa(replicas),
+			"creation.date": fmt.Sprint(idx.settings["index.creation_date"]),
+		}
+		if idx.closed {
+			row["status"] = "close"
+			for _, stat := range []string{"docs.count", "docs.deleted", "store.size", "pri.store.size"} {
+				row[stat] = nil
+			}
+		} else {
+			size := 0
+			for _, doc := range idx.docs {
+				size += len(fmt.Sprint(doc.source))
+			}
+			row["docs.count"] = strconv.Itoa(len(idx.docs))
+			row["docs.deleted"] = "0"
+			row["store.size"] = strconv.Itoa(size)
+			row["pri.store.size"] = strconv.Itoa(size)
+		}
+		rows = append(rows, row)
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+func (s *Server) catAliases(w http.ResponseWriter, r *request) {
+	pattern := r.arg(2)
+	rows := []map[string]string{}
+	for name, idx := range s.indices {
+		for alias, config := range idx.aliases {
+			if pattern != "" && !matchPattern(pattern, alias) {
+				continue
+			}
+			isWriteIndex := "-"
+			if v, ok := config["is_write_index"].(bool); ok {
+				isWriteIndex = strconv.FormatBool(v)
+			}
+			rows = append(rows, map[string]string{
+				"alias":          alias,
+				"index":          name,
+				"filter":         "-",
+				"routing.index":  "-",
+				"routing.search": "-",
+				"is_write_index": isWriteIndex,
+			})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i]["alias"]+"/"+rows[i]["index"] < rows[j]["alias"]+"/"+rows[j]["index"]
+	})
+	writeJSON(w, http.StatusOK, rows)
+}
+
+func (s *Server) handleDocument(w http.ResponseWriter, r *request) {
+	name, id := r.arg(0), r.arg(2)
+	idx, exists := s.indices[name]
+
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		var source map[string]interface{}
+		if !r.decode(w, &source) {
+			return
+		}
+		if !exists {
+			// Indices are created on the first write of a document.
+			idx = s.newIndex(name)
+			s.indices[name] = idx
+		}
+		if id == "" {
+			id = s.newID()
+		}
+		doc := idx.docs[id]
+		if !checkVersion(w, r, doc) {
+			return
+		}
+		result, status := "created", http.StatusCreated
+		if doc != nil {
+			result, status = "updated", http.StatusOK
+		}
+		doc = s.save(doc, id, source)
+		idx.docs[id] = doc
+		writeJSON(w, status, documentResponse(name, doc, map[string]interface{}{
+			"result":  result,
+			"_shards": map[string]interface{}{"total": 2, "successful": 1, "failed": 0},
+		}))
+	case http.MethodGet, http.MethodHead:
+		if !exists {
+			writeIndexNotFound(w, name)
+			return
+		}
+		doc, ok := idx.docs[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"_index": name, "_id": id, "found": false})
+			return
+		}
+		writeJSON(w, http.StatusOK, documentResponse(name, doc, map[string]interface{}{
+			"found":   true,
+			"_source": doc.source,
+		}))
+	case http.MethodDelete:
+		if !exists {
+			writeIndexNotFound(w, name)
+			return
+		}
+		doc, ok := idx.docs[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"_index": name, "_id": id, "result": "not_found"})
+			return
+		}
+		delete(idx.docs, id)
+		writeJSON(w, http.StatusOK, documentResponse(name, doc, map[string]interface{}{"result": "deleted"}))
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func documentResponse(name string, doc *document, fields map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{
+		"_index":        name,
+		"_id":           doc.id,
+		"_version":      doc.version,
+		"_seq_no":       doc.seqNo,
+		"_primary_term": doc.primaryTerm,
+	}
+	for k, v := range fields {
+		res[k] = v
+	}
+	return res
+}
+
+// handleTemplate serves the API of index or component templates stored in
+// templates, listed as listKey in responses with the template as itemKey.
+func (s *Server) handleTemplate(w http.ResponseWriter, r *request, templates map[string]map[string]interface{}, listKey, itemKey string) {
+	name := r.arg(1)
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		list := []interface{}{}
+		for _, n := range sortedKeys(templates) {
+			if name == "" || matchPattern(name, n) {
+				list = append(list, map[string]interface{}{"name": n, itemKey: templates[n]})
+			}
+		}
+		if len(list) == 0 && name != "" && !strings.Contains(name, "*") {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("%s matching [%s] not found", strings.ReplaceAll(itemKey, "_", " "), name))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{listKey: list})
+	case http.MethodPut, http.MethodPost:
+		if _, exists := templates[name]; exists && r.URL.Query().Get("create") == "true" {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("%s [%s] already exists", strings.ReplaceAll(itemKey, "_", " "), name))
+			return
+		}
+		var body map[string]interface{}
+		if !r.decode(w, &body) {
+			return
+		}
+		// Settings are returned as OpenSearch stores them, nested under
+		// `index` with string values.
+		if template, ok := body["template"].(map[string]interface{}); ok {
+			if settings, ok := template["settings"].(map[string]interface{}); ok {
+				template["settings"] = nestSettings(indexSettings(settings))
+			}
+		}
+		templates[name] = body
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		if _, exists := templates[name]; !exists {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("%s matching [%s] not found", strings.ReplaceAll(itemKey, "_", " "), name))
+			return
+		}
+		delete(templates, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleDataStream(w http.ResponseWriter, r *request) {
+	name := r.arg(1)
+
+	switch r.Method {
+	case http.MethodGet:
+		list := []interface{}{}
+		for _, n := range sortedKeys(s.dataStreams) {
+			if name == "" || matchPattern(name, n) {
+				list = append(list, map[string]interface{}{
+					"name":            n,
+					"timestamp_field": map[string]interface{}{"name": "@timestamp"},
+					"indices":         []interface{}{map[string]interface{}{"index_name": fmt.Sprintf(".ds-%s-000001", n)}},
+					"generation":      1,
+					"status":          "GREEN",
+					"template":        s.dataStreamTemplate(n),
+				})
+			}
+		}
+		if len(list) == 0 && name != "" && !strings.Contains(name, "*") {
+			writeIndexNotFound(w, name)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data_streams": list})
+	case http.MethodPut:
+		if s.dataStreams[name] {
+			writeError(w, http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("data_stream [%s] already exists", name))
+			return
+		}
+		if s.dataStreamTemplate(name) == "" {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("no matching index template found for data stream [%s]", name))
+			return
+		}
+		s.dataStreams[name] = true
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		if !s.dataStreams[name] {
+			writeIndexNotFound(w, name)
+			return
+		}
+		delete(s.dataStreams, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// dataStreamTemplate returns the name of the index template enabling the data
+// stream name, or "" if none does.
+func (s *Server) dataStreamTemplate(name string) string {
+	for _, n := range sortedKeys(s.indexTemplates) {
+		template := s.indexTemplates[n]
+		if _, ok := template["data_stream"]; !ok {
+			continue
+		}
+		patterns, _ := template["index_patterns"].([]interface{})
+		for _, p := range patterns {
+			if pattern, ok := p.(string); ok && matchPattern(pattern, name) {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
+// simulateIndex answers the template an index would be created with: the
+// template of the matching index template with the highest priority, merged
+// over the ones of its component templates, and the other matching index
+// templates as overlapping. Like OpenSearch, it answers an empty object if no
+// index template matches.
+func (s *Server) simulateIndex(w http.ResponseWriter, r *request) {
+	if r.Method != http.MethodPost {
+		writeUnsupported(w, r)
+		return
+	}
+	name := r.arg(2)
+
+	var matching []string
+	for _, n := range sortedKeys(s.indexTemplates) {
+		patterns, _ := s.indexTemplates[n]["index_patterns"].([]interface{})
+		for _, p := range patterns {
+			if pattern, ok := p.(string); ok && matchPattern(pattern, name) {
+				matching = append(matching, n)
+				break
+			}
+		}
+	}
+	if len(matching) == 0 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		pi, _ := s.indexTemplates[matching[i]]["priority"].(float64)
+		pj, _ := s.indexTemplates[matching[j]]["priority"].(float64)
+		return pi > pj
+	})
+
+	resolved := map[string]interface{}{
+		"settings": map[string]interface{}{},
+		"mappings": map[string]interface{}{},
+		"aliases":  map[string]interface{}{},
+	}
+	merge := func(template map[string]interface{}) {
+		if template == nil {
+			return
+		}
+		mergeMaps(resolved, cloneMap(template))
+	}
+	winner := s.indexTemplates[matching[0]]
+	composedOf, _ := winner["composed_of"].([]interface{})
+	for _, c := range composedOf {
+		if component, ok := s.componentTemplates[fmt.Sprint(c)]; ok {
+			template, _ := component["template"].(map[string]interface{})
+			merge(template)
+		}
+	}
+	template, _ := winner["template"].(map[string]interface{})
+	merge(template)
+
+	overlapping := []interface{}{}
+	for _, n := range matching[1:] {
+		overlapping = append(overlapping, map[string]interface{}{
+			"name":           n,
+			"index_patterns": s.indexTemplates[n]["index_patterns"],
+		})
+	}
+	sort.Slice(overlapping, func(i, j int) bool {
+		return overlapping[i].(map[string]interface{})["name"].(string) < overlapping[j].(map[string]interface{})["name"].(string)
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"template":    resolved,
+		"overlapping": overlapping,
+	})
+}
+
+func (s *Server) handlePipeline(w http.ResponseWriter, r *request) {
+	if r.arg(1) != "pipeline" {
+		writeUnsupported(w, r)
+		return
+	}
+	id := r.arg(2)
+
+	switch r.Method {
+	case http.MethodGet:
+		res := map[string]interface{}{}
+		for _, n := range sortedKeys(s.pipelines) {
+			if id == "" || matchPattern(id, n) {
+				res[n] = s.pipelines[n]
+			}
+		}
+		if len(res) == 0 && id != "" {
+			// OpenSearch answers an empty object for a missing pipeline.
+			writeJSON(w, http.StatusNotFound, res)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut:
+		var body map[string]interface{}
+		if !r.decode(w, &body) {
+			return
+		}
+		s.pipelines[id] = body
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		if _, ok := s.pipelines[id]; !ok {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("pipeline [%s] is missing", id))
+			return
+		}
+		delete(s.pipelines, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleScript(w http.ResponseWriter, r *request) {
+	id := r.arg(1)
+
+	switch r.Method {
+	case http.MethodGet:
+		script, ok := s.scripts[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"_id": id, "found": false})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"_id": id, "found": true, "script": script})
+	case http.MethodPut, http.MethodPost:
+		var body struct {
+			Script map[string]interface{} `json:"script"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		if body.Script == nil {
+			writeError(w, http.StatusBadRequest, "parse_exception", "must specify a script")
+			return
+		}
+		s.scripts[id] = body.Script
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		if _, ok := s.scripts[id]; !ok {
+			writeError(w, http.StatusNotFound, "resource_not_found_exception", fmt.Sprintf("stored script [%s] does not exist and cannot be deleted", id))
+			return
+		}
+		delete(s.scripts, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleRepository(w http.ResponseWriter, r *request) {
+	name := r.arg(1)
+
+	switch r.Method {
+	case http.MethodGet:
+		res := map[string]interface{}{}
+		for _, n := range sortedKeys(s.repositories) {
+			if name == "" || matchPattern(name, n) {
+				res[n] = s.repositories[n]
+			}
+		}
+		if len(res) == 0 && name != "" && !strings.Contains(name, "*") {
+			writeError(w, http.StatusNotFound, "repository_missing_exception", fmt.Sprintf("[%s] missing", name))
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut, http.MethodPost:
+		var body struct {
+			Type     string                 `json:"type"`
+			Settings map[string]interface{} `json:"settings"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		if body.Type == "" {
+			writeError(w, http.StatusBadRequest, "repository_exception", fmt.Sprintf("[%s] missing repository type", name))
+			return
+		}
+		// Settings are returned with string values.
+		settings := map[string]interface{}{}
+		flattenSettings("", body.Settings, settings)
+		s.repositories[name] = map[string]interface{}{"type": body.Type, "settings": nestSettings(settings)}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	case http.MethodDelete:
+		if _, ok := s.repositories[name]; !ok {
+			writeError(w, http.StatusNotFound, "repository_missing_exception", fmt.Sprintf("[%s] missing", name))
+			return
+		}
+		delete(s.repositories, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"acknowledged": true})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// indexSettings flattens settings and prefixes their keys with `index.` as
+// OpenSearch does.
+func indexSettings(settings map[string]interface{}) map[string]interface{} {
+	flat := map[string]interface{}{}
+	flattenSettings("", settings, flat)
+	prefixed := map[string]interface{}{}
+	for key, value := range flat {
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+		prefixed[key] = value
+	}
+	return prefixed
+}
+
+// flattenSettings adds the settings of the nested object settings to flat,
+// with keys joined by dots and scalar values converted to strings.
+func flattenSettings(prefix string, settings map[string]interface{}, flat map[string]interface{}) {
+	for key, value := range settings {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenSettings(prefix+key+".", v, flat)
+		case []interface{}:
+			list := make([]interface{}, 0, len(v))
+			for _, item := range v {
+				list = append(list, settingValue(item))
+			}
+			flat[prefix+key] = list
+		default:
+			flat[prefix+key] = settingValue(v)
+		}
+	}
+}
+
+func settingValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// nestSettings returns flat settings as a nested object.
+func nestSettings(flat map[string]interface{}) map[string]interface{} {
+	nested := map[string]interface{}{}
+	for _, key := range sortedKeys(flat) {
+		parts := strings.Split(key, ".")
+		m := nested
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := m[part].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				m[part] = child
+			}
+			m = child
+		}
+		m[parts[len(parts)-1]] = flat[key]
+	}
+	return nested
+}
+
+// mergeMaps merges src into dst recursively.
+func mergeMaps(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if s, ok := value.(map[string]interface{}); ok {
+			if d, ok := dst[key].(map[string]interface{}); ok {
+				mergeMaps(d, s)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// cloneMap returns a deep copy of the nested objects of m, so that merging
+// into it doesn't change the objects it was copied from.
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		if v, ok := value.(map[string]interface{}); ok {
+			value = cloneMap(v)
+		}
+		c[key] = value
+	}
+	return c
+}
+
+// resolveDateMath resolves the date math expressions of an index name such as
+// `<logs-{now/d}>` to the current date.
+func resolveDateMath(name string) string {
+	if !strings.HasPrefix(name, "<") || !strings.HasSuffix(name, ">") {
+		return name
+	}
+	name = strings.TrimSuffix(strings.TrimPrefix(name, "<"), ">")
+	var resolved strings.Builder
+	for {
+		start := strings.Index(name, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(name[start:], "}")
+		if end < 0 {
+			break
+		}
+		resolved.WriteString(name[:start])
+		resolved.WriteString(time.Now().UTC().Format("2006.01.02"))
+		name = name[start+end+1:]
+	}
+	resolved.WriteString(name)
+	return resolved.String()
+}
+
+// matchPattern reports whether name matches pattern, which may contain `*`
+// wildcards.
+func matchPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
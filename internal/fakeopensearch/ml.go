@@ -0,0 +1,204 @@
+package fakeopensearch
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) handleML(w http.ResponseWriter, r *request) {
+	switch r.arg(2) {
+	case "connectors":
+		s.handleMLObject(w, r, s.mlConnectors, "connector", "_create")
+	case "model_groups":
+		s.handleMLObject(w, r, s.mlModelGroups, "model_group", "_register")
+	case "models":
+		s.handleMLModel(w, r)
+	case "tasks":
+		task, ok := s.mlTasks[r.arg(3)]
+		if r.Method != http.MethodGet || !ok {
+			writeError(w, http.StatusNotFound, "status_exception", "Failed to find task")
+			return
+		}
+		writeJSON(w, http.StatusOK, task)
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// handleMLObject serves the API of connectors and model groups, which are
+// created with a POST to action and answered with an `{kind}_id`.
+func (s *Server) handleMLObject(w http.ResponseWriter, r *request, objects map[string]map[string]interface{}, kind, action string) {
+	id := r.arg(3)
+	object, ok := objects[id]
+
+	switch {
+	case r.Method == http.MethodPost && id == action:
+		var object map[string]interface{}
+		if !r.decode(w, &object) {
+			return
+		}
+		id = s.newID()
+		objects[id] = s.newMLObject(kind, object)
+		writeJSON(w, http.StatusOK, map[string]interface{}{kind + "_id": id, "status": "CREATED"})
+	case id == "" || strings.HasPrefix(id, "_"):
+		writeUnsupported(w, r)
+	case !ok:
+		writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Failed to find %s with the provided %s id: %s", strings.ReplaceAll(kind, "_", " "), kind, id))
+	case r.Method == http.MethodGet:
+		res := map[string]interface{}{}
+		for key, value := range object {
+			// Credentials of connectors are encrypted and never returned.
+			if key != "credential" {
+				res[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, res)
+	case r.Method == http.MethodPut:
+		var update map[string]interface{}
+		if !r.decode(w, &update) {
+			return
+		}
+		for key, value := range s.newMLObject(kind, update) {
+			if _, created := update[key]; created || key == "last_updated_time" {
+				object[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"_id": id, "result": "updated"})
+	case r.Method == http.MethodDelete:
+		delete(objects, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"_id": id, "result": "deleted"})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// newMLObject returns object as the ML plugin stores it: model groups replace
+// `access_mode` with `access`.
+func (s *Server) newMLObject(kind string, object map[string]interface{}) map[string]interface{} {
+	now := time.Now().UnixMilli()
+	object["created_time"] = now
+	object["last_updated_time"] = now
+	if kind == "model_group" {
+		if mode, ok := object["access_mode"]; ok {
+			object["access"] = mode
+			delete(object, "access_mode")
+		} else if _, ok := object["access"]; !ok {
+			object["access"] = "private"
+		}
+		object["latest_version"] = 0
+	}
+	return object
+}
+
+func (s *Server) handleMLModel(w http.ResponseWriter, r *request) {
+	id := r.arg(3)
+	model, ok := s.mlModels[id]
+
+	switch {
+	case r.Method == http.MethodPost && id == "_register":
+		s.registerMLModel(w, r)
+	case id == "" || strings.HasPrefix(id, "_"):
+		writeUnsupported(w, r)
+	case !ok:
+		writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Failed to find model with the provided model id: %s", id))
+	case r.Method == http.MethodPost && r.arg(4) == "_deploy":
+		model["model_state"] = "DEPLOYED"
+		writeJSON(w, http.StatusOK, map[string]interface{}{"task_id": s.newID(), "task_type": "DEPLOY_MODEL", "status": "COMPLETED"})
+	case r.Method == http.MethodPost && r.arg(4) == "_undeploy":
+		model["model_state"] = "UNDEPLOYED"
+		writeJSON(w, http.StatusOK, map[string]interface{}{"fake-node": map[string]interface{}{"stats": map[string]interface{}{id: "UNDEPLOYED"}}})
+	case r.Method == http.MethodPost && r.arg(4) == "_predict":
+		if model["is_enabled"] == false {
+			writeError(w, http.StatusForbidden, "status_exception", "Model is disabled.")
+			return
+		}
+		if model["model_state"] != "DEPLOYED" {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Model not ready yet. Please run this first: POST /_plugins/_ml/models/%s/_deploy", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"inference_results": []interface{}{
+				map[string]interface{}{
+					"output": []interface{}{
+						map[string]interface{}{"name": "response", "dataAsMap": map[string]interface{}{}},
+					},
+					"status_code": 200,
+				},
+			},
+		})
+	case r.arg(4) != "":
+		writeUnsupported(w, r)
+	case r.Method == http.MethodGet:
+		res := map[string]interface{}{}
+		for key, value := range model {
+			// Whether a model is enabled isn't returned.
+			if key != "is_enabled" {
+				res[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, res)
+	case r.Method == http.MethodPut:
+		var update map[string]interface{}
+		if !r.decode(w, &update) {
+			return
+		}
+		for key, value := range update {
+			model[key] = value
+		}
+		model["last_updated_time"] = time.Now().UnixMilli()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"_id": id, "result": "updated"})
+	case r.Method == http.MethodDelete:
+		if model["model_state"] == "DEPLOYED" {
+			writeError(w, http.StatusBadRequest, "status_exception", "Model cannot be deleted in deploying or deployed state. Try undeploy model first then delete")
+			return
+		}
+		delete(s.mlModels, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"_id": id, "result": "deleted"})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// registerMLModel registers a model synchronously if it's served by a
+// connector, like OpenSearch does for remote models, and through a completed
+// task otherwise.
+func (s *Server) registerMLModel(w http.ResponseWriter, r *request) {
+	var model map[string]interface{}
+	if !r.decode(w, &model) {
+		return
+	}
+	if groupID, ok := model["model_group_id"].(string); ok {
+		if _, exists := s.mlModelGroups[groupID]; !exists {
+			writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Failed to find model group with ID: %s", groupID))
+			return
+		}
+	}
+
+	id := s.newID()
+	now := time.Now().UnixMilli()
+	model["model_state"] = "REGISTERED"
+	model["created_time"] = now
+	model["last_updated_time"] = now
+	if function, ok := model["function_name"].(string); ok {
+		model["algorithm"] = strings.ToUpper(function)
+		delete(model, "function_name")
+	}
+	if _, ok := model["is_enabled"]; !ok {
+		model["is_enabled"] = true
+	}
+	s.mlModels[id] = model
+
+	if _, remote := model["connector_id"]; remote {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"task_id": s.newID(), "status": "CREATED", "model_id": id})
+		return
+	}
+	taskID := s.newID()
+	s.mlTasks[taskID] = map[string]interface{}{
+		"model_id":  id,
+		"task_type": "REGISTER_MODEL",
+		"state":     "COMPLETED",
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"task_id": taskID, "status": "CREATED"})
+}
@@ -0,0 +1,400 @@
+package fakeopensearch
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// versionedObjects serves the API of the objects of a plugin stored as
+// documents, e.g. monitors, which are created with a POST and returned with
+// their version under key.
+type versionedObjects struct {
+	documents map[string]*document
+	// system index of the plugin, returned in the response to a DELETE
+	index string
+	key   string
+	// reason of the error for a missing object
+	notFound func(id string) string
+	// adds the fields OpenSearch manages to an object as it is stored
+	decorate func(object map[string]interface{})
+}
+
+func (s *Server) handleVersioned(w http.ResponseWriter, r *request, objects versionedObjects, id string) {
+	doc := objects.documents[id]
+	respond := func(status int, doc *document) {
+		writeJSON(w, status, map[string]interface{}{
+			"_id":           doc.id,
+			"_version":      doc.version,
+			"_seq_no":       doc.seqNo,
+			"_primary_term": doc.primaryTerm,
+			objects.key:     doc.source,
+		})
+	}
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		var object map[string]interface{}
+		if !r.decode(w, &object) {
+			return
+		}
+		objects.decorate(object)
+		doc = s.save(nil, s.newID(), object)
+		objects.documents[doc.id] = doc
+		respond(http.StatusCreated, doc)
+	case id == "":
+		writeUnsupported(w, r)
+	case doc == nil:
+		writeError(w, http.StatusNotFound, "status_exception", objects.notFound(id))
+	case r.Method == http.MethodGet:
+		respond(http.StatusOK, doc)
+	case r.Method == http.MethodPut:
+		if !checkVersion(w, r, doc) {
+			return
+		}
+		var object map[string]interface{}
+		if !r.decode(w, &object) {
+			return
+		}
+		objects.decorate(object)
+		respond(http.StatusOK, s.save(doc, id, object))
+	case r.Method == http.MethodDelete:
+		delete(objects.documents, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_index":   objects.index,
+			"_id":      id,
+			"_version": doc.version + 1,
+			"result":   "deleted",
+		})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleMonitor(w http.ResponseWriter, r *request) {
+	if r.arg(2) != "monitors" {
+		writeUnsupported(w, r)
+		return
+	}
+	s.handleVersioned(w, r, versionedObjects{
+		documents: s.monitors,
+		index:     ".opendistro-alerting-config",
+		key:       "monitor",
+		notFound:  func(string) string { return "Monitor not found." },
+		decorate: func(monitor map[string]interface{}) {
+			monitor["last_update_time"] = time.Now().UnixMilli()
+			monitor["schema_version"] = 5
+		},
+	}, r.arg(3))
+}
+
+func (s *Server) handleDetector(w http.ResponseWriter, r *request) {
+	if r.arg(2) != "detectors" {
+		writeUnsupported(w, r)
+		return
+	}
+	s.handleVersioned(w, r, versionedObjects{
+		documents: s.detectors,
+		index:     ".opendistro-anomaly-detectors",
+		key:       "anomaly_detector",
+		notFound:  func(id string) string { return fmt.Sprintf("Can't find detector with id: %s", id) },
+		decorate: func(detector map[string]interface{}) {
+			detector["last_update_time"] = time.Now().UnixMilli()
+		},
+	}, r.arg(3))
+}
+
+func (s *Server) handleISM(w http.ResponseWriter, r *request) {
+	switch action := r.arg(2); action {
+	case "policies":
+		s.handleISMPolicy(w, r)
+	case "add", "change_policy", "remove":
+		s.handleISMManagedIndices(w, r, action)
+	case "explain":
+		if r.Method != http.MethodGet {
+			writeUnsupported(w, r)
+			return
+		}
+		names, ok := s.mustResolveIndices(w, r.arg(3))
+		if !ok {
+			return
+		}
+		res := map[string]interface{}{}
+		managed := 0
+		for _, name := range names {
+			var policyID interface{}
+			if id := s.indices[name].ismPolicyID; id != "" {
+				policyID = id
+				managed++
+			}
+			res[name] = map[string]interface{}{
+				"index.plugins.index_state_management.policy_id":    policyID,
+				"index.opendistro.index_state_management.policy_id": policyID,
+				"index":     name,
+				"policy_id": policyID,
+				"enabled":   policyID != nil,
+			}
+		}
+		res["total_managed_indices"] = managed
+		writeJSON(w, http.StatusOK, res)
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleISMPolicy(w http.ResponseWriter, r *request) {
+	id := r.arg(3)
+	doc := s.ismPolicies[id]
+
+	switch {
+	case r.Method == http.MethodPut && id != "":
+		if doc != nil && r.URL.Query().Get("if_seq_no") == "" {
+			writeError(w, http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[%s]: version conflict, document already exists (current version [%d])", id, doc.version))
+			return
+		}
+		if !checkVersion(w, r, doc) {
+			return
+		}
+		var body struct {
+			Policy map[string]interface{} `json:"policy"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		if body.Policy == nil {
+			writeError(w, http.StatusBadRequest, "illegal_argument_exception", "Required [policy] parameter is missing")
+			return
+		}
+		policy := body.Policy
+		policy["policy_id"] = id
+		policy["last_updated_time"] = time.Now().UnixMilli()
+		policy["schema_version"] = 21
+		for _, key := range []string{"error_notification", "ism_template"} {
+			if _, ok := policy[key]; !ok {
+				policy[key] = nil
+			}
+		}
+		status := http.StatusOK
+		if doc == nil {
+			status = http.StatusCreated
+		}
+		doc = s.save(doc, id, policy)
+		s.ismPolicies[id] = doc
+		writeJSON(w, status, map[string]interface{}{
+			"_id":           id,
+			"_version":      doc.version,
+			"_primary_term": doc.primaryTerm,
+			"_seq_no":       doc.seqNo,
+			"policy":        map[string]interface{}{"policy": policy},
+		})
+	case id == "":
+		writeUnsupported(w, r)
+	case doc == nil:
+		writeError(w, http.StatusNotFound, "status_exception", "Policy not found")
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_id":           id,
+			"_version":      doc.version,
+			"_seq_no":       doc.seqNo,
+			"_primary_term": doc.primaryTerm,
+			"policy":        doc.source,
+		})
+	case r.Method == http.MethodDelete:
+		delete(s.ismPolicies, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_index":   ".opendistro-ism-config",
+			"_id":      id,
+			"_version": doc.version + 1,
+			"result":   "deleted",
+		})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// handleISMManagedIndices adds, changes or removes the policy managing the
+// indices matched by the path.
+func (s *Server) handleISMManagedIndices(w http.ResponseWriter, r *request, action string) {
+	if r.Method != http.MethodPost {
+		writeUnsupported(w, r)
+		return
+	}
+	var body struct {
+		PolicyID string `json:"policy_id"`
+	}
+	if action != "remove" && !r.decode(w, &body) {
+		return
+	}
+	if action != "remove" && s.ismPolicies[body.PolicyID] == nil {
+		writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Could not find policy=%s", body.PolicyID))
+		return
+	}
+
+	names, ok := s.mustResolveIndices(w, r.arg(3))
+	if !ok {
+		return
+	}
+	updated := 0
+	failed := []interface{}{}
+	for _, name := range names {
+		idx := s.indices[name]
+		managed := idx.ismPolicyID != ""
+		switch {
+		case action == "add" && managed:
+			failed = append(failed, map[string]interface{}{"index_name": name, "reason": "This index already has a policy, use the update policy API to update index policies"})
+			continue
+		case action != "add" && !managed:
+			failed = append(failed, map[string]interface{}{"index_name": name, "reason": "This index does not have a policy to update"})
+			continue
+		}
+		idx.ismPolicyID = body.PolicyID
+		updated++
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"updated_indices": updated,
+		"failures":        len(failed) > 0,
+		"failed_indices":  failed,
+	})
+}
+
+func (s *Server) handleSM(w http.ResponseWriter, r *request) {
+	name := r.arg(3)
+	if r.arg(2) != "policies" || name == "" {
+		writeUnsupported(w, r)
+		return
+	}
+	doc := s.smPolicies[name]
+	respond := func(status int, doc *document) {
+		writeJSON(w, status, map[string]interface{}{
+			"_id":           name + "-sm-policy",
+			"_version":      doc.version,
+			"_seq_no":       doc.seqNo,
+			"_primary_term": doc.primaryTerm,
+			"sm_policy":     doc.source,
+		})
+	}
+
+	switch {
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		if r.Method == http.MethodPost && doc != nil {
+			writeError(w, http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[%s-sm-policy]: version conflict, document already exists (current version [%d])", name, doc.version))
+			return
+		}
+		if r.Method == http.MethodPut && doc == nil {
+			writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Snapshot management policy [%s] not found.", name))
+			return
+		}
+		if !checkVersion(w, r, doc) {
+			return
+		}
+		var policy map[string]interface{}
+		if !r.decode(w, &policy) {
+			return
+		}
+		now := time.Now().UnixMilli()
+		policy["name"] = name
+		policy["schema_version"] = 21
+		policy["last_updated_time"] = now
+		policy["enabled_time"] = now
+		status := http.StatusOK
+		if doc == nil {
+			status = http.StatusCreated
+		}
+		doc = s.save(doc, name+"-sm-policy", policy)
+		s.smPolicies[name] = doc
+		respond(status, doc)
+	case doc == nil:
+		writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("Snapshot management policy [%s] not found.", name))
+	case r.Method == http.MethodGet:
+		respond(http.StatusOK, doc)
+	case r.Method == http.MethodDelete:
+		delete(s.smPolicies, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_index":   ".opendistro-ism-config",
+			"_id":      name + "-sm-policy",
+			"_version": doc.version + 1,
+			"result":   "deleted",
+		})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleChannel(w http.ResponseWriter, r *request) {
+	if r.arg(2) != "configs" {
+		writeUnsupported(w, r)
+		return
+	}
+	id := r.arg(3)
+	doc := s.channels[id]
+	list := func(docs ...*document) map[string]interface{} {
+		configs := []interface{}{}
+		for _, doc := range docs {
+			configs = append(configs, doc.source)
+		}
+		return map[string]interface{}{
+			"start_index":        0,
+			"total_hits":         len(configs),
+			"total_hit_relation": "eq",
+			"config_list":        configs,
+		}
+	}
+	save := func(doc *document, id string, config map[string]interface{}) {
+		now := time.Now().UnixMilli()
+		created := now
+		if doc != nil {
+			created = doc.source["created_time_ms"].(int64)
+		}
+		s.channels[id] = s.save(doc, id, map[string]interface{}{
+			"config_id":            id,
+			"last_updated_time_ms": now,
+			"created_time_ms":      created,
+			"config":               config,
+		})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"config_id": id})
+	}
+
+	switch {
+	case r.Method == http.MethodPost && id == "":
+		var body struct {
+			ConfigID string                 `json:"config_id"`
+			Config   map[string]interface{} `json:"config"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		if body.ConfigID == "" {
+			body.ConfigID = s.newID()
+		}
+		if s.channels[body.ConfigID] != nil {
+			writeError(w, http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[%s]: version conflict, document already exists", body.ConfigID))
+			return
+		}
+		save(nil, body.ConfigID, body.Config)
+	case r.Method == http.MethodGet && id == "":
+		var docs []*document
+		for _, id := range sortedKeys(s.channels) {
+			docs = append(docs, s.channels[id])
+		}
+		writeJSON(w, http.StatusOK, list(docs...))
+	case id == "":
+		writeUnsupported(w, r)
+	case doc == nil:
+		writeError(w, http.StatusNotFound, "status_exception", fmt.Sprintf("NotificationConfig %s not found", id))
+	case r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, list(doc))
+	case r.Method == http.MethodPut:
+		var body struct {
+			Config map[string]interface{} `json:"config"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		save(doc, id, body.Config)
+	case r.Method == http.MethodDelete:
+		delete(s.channels, id)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"delete_response_list": map[string]interface{}{id: "OK"}})
+	default:
+		writeUnsupported(w, r)
+	}
+}
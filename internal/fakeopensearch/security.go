@@ -0,0 +1,240 @@
+package fakeopensearch
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// securityKinds are the kinds of objects of the REST API of the security
+// plugin, by their path segment.
+var securityKinds = map[string]bool{
+	"actiongroups":  true,
+	"internalusers": true,
+	"roles":         true,
+	"rolesmapping":  true,
+	"tenants":       true,
+}
+
+func (s *Server) handleSecurity(w http.ResponseWriter, r *request) {
+	if r.arg(2) != "api" {
+		writeUnsupported(w, r)
+		return
+	}
+	kind, name := r.arg(3), r.arg(4)
+	if kind == "audit" {
+		s.handleAudit(w, r)
+		return
+	}
+	if !securityKinds[kind] {
+		writeUnsupported(w, r)
+		return
+	}
+	objects := s.security[kind]
+	if objects == nil {
+		objects = map[string]map[string]interface{}{}
+		s.security[kind] = objects
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		res := map[string]interface{}{}
+		if name == "" {
+			for n, object := range objects {
+				res[n] = object
+			}
+			writeJSON(w, http.StatusOK, res)
+			return
+		}
+		object, ok := objects[name]
+		if !ok {
+			writeSecurityNotFound(w, name)
+			return
+		}
+		res[name] = object
+		writeJSON(w, http.StatusOK, res)
+	case http.MethodPut:
+		var object map[string]interface{}
+		if !r.decode(w, &object) {
+			return
+		}
+		if !writableSecurityObject(w, objects[name], name) {
+			return
+		}
+		if kind == "internalusers" {
+			// Passwords are stored as a hash, which isn't returned.
+			delete(object, "password")
+			object["hash"] = ""
+		}
+		object["reserved"] = false
+		object["hidden"] = false
+		object["static"] = false
+
+		if _, exists := objects[name]; exists {
+			objects[name] = object
+			writeJSON(w, http.StatusOK, map[string]interface{}{"status": "OK", "message": fmt.Sprintf("'%s' updated.", name)})
+			return
+		}
+		objects[name] = object
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"status": "CREATED", "message": fmt.Sprintf("'%s' created.", name)})
+	case http.MethodDelete:
+		object, ok := objects[name]
+		if !ok {
+			writeSecurityNotFound(w, name)
+			return
+		}
+		if !writableSecurityObject(w, object, name) {
+			return
+		}
+		delete(objects, name)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "OK", "message": fmt.Sprintf("'%s' deleted.", name)})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// writeSecurityNotFound writes a missing object error, which the security
+// plugin answers in its own format.
+func writeSecurityNotFound(w http.ResponseWriter, name string) {
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{
+		"status":  "NOT_FOUND",
+		"message": fmt.Sprintf("Resource '%s' not found.", name),
+	})
+}
+
+// writableSecurityObject writes the error of a change of a reserved or static
+// object, which the security plugin forbids, and reports whether there was
+// none.
+func writableSecurityObject(w http.ResponseWriter, object map[string]interface{}, name string) bool {
+	for _, flag := range []string{"reserved", "static"} {
+		if object[flag] == true {
+			writeJSON(w, http.StatusForbidden, map[string]interface{}{
+				"status":  "FORBIDDEN",
+				"message": fmt.Sprintf("Resource '%s' is %s.", name, flag),
+			})
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) handleAudit(w http.ResponseWriter, r *request) {
+	switch {
+	case r.Method == http.MethodGet && r.arg(4) == "":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"_readonly": []interface{}{},
+			"config":    s.auditConfig,
+		})
+	case r.Method == http.MethodPut && r.arg(4) == "config":
+		var config map[string]interface{}
+		if !r.decode(w, &config) {
+			return
+		}
+		s.auditConfig = config
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "OK", "message": "'config' updated."})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func defaultAuditConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"enabled": true,
+		"audit": map[string]interface{}{
+			"enable_rest":                   true,
+			"disabled_rest_categories":      []interface{}{"AUTHENTICATED", "GRANTED_PRIVILEGES"},
+			"enable_transport":              true,
+			"disabled_transport_categories": []interface{}{"AUTHENTICATED", "GRANTED_PRIVILEGES"},
+			"resolve_bulk_requests":         false,
+			"log_request_body":              true,
+			"resolve_indices":               true,
+			"exclude_sensitive_headers":     true,
+			"ignore_users":                  []interface{}{"kibanaserver"},
+			"ignore_requests":               []interface{}{},
+		},
+		"compliance": map[string]interface{}{
+			"enabled":               true,
+			"internal_config":       true,
+			"external_config":       false,
+			"read_metadata_only":    true,
+			"read_watched_fields":   map[string]interface{}{},
+			"read_ignore_users":     []interface{}{"kibanaserver"},
+			"write_metadata_only":   true,
+			"write_log_diffs":       false,
+			"write_watched_indices": []interface{}{},
+			"write_ignore_users":    []interface{}{"kibanaserver"},
+		},
+	}
+}
+
+// defaultSecurityObjects returns some of the built-in objects of the security
+// plugin, which are reserved or static like on a real cluster.
+func defaultSecurityObjects() map[string]map[string]map[string]interface{} {
+	flags := func(reserved, static bool) map[string]interface{} {
+		return map[string]interface{}{"reserved": reserved, "hidden": false, "static": static}
+	}
+	with := func(object, flags map[string]interface{}) map[string]interface{} {
+		for k, v := range flags {
+			object[k] = v
+		}
+		return object
+	}
+
+	return map[string]map[string]map[string]interface{}{
+		"actiongroups": {
+			"read": with(map[string]interface{}{
+				"allowed_actions": []interface{}{"indices:data/read*", "indices:admin/mappings/fields/get*", "indices:admin/resolve/index"},
+				"type":            "index",
+				"description":     "Allow all read operations",
+			}, flags(true, true)),
+		},
+		"internalusers": {
+			"admin": with(map[string]interface{}{
+				"hash":          "",
+				"backend_roles": []interface{}{"admin"},
+				"attributes":    map[string]interface{}{},
+				"description":   "Demo admin user",
+			}, flags(true, false)),
+		},
+		"roles": {
+			"all_access": with(map[string]interface{}{
+				"description":         "Allow full access to all indices and all cluster APIs",
+				"cluster_permissions": []interface{}{"*"},
+				"index_permissions": []interface{}{map[string]interface{}{
+					"index_patterns":  []interface{}{"*"},
+					"fls":             []interface{}{},
+					"masked_fields":   []interface{}{},
+					"allowed_actions": []interface{}{"*"},
+				}},
+				"tenant_permissions": []interface{}{map[string]interface{}{
+					"tenant_patterns": []interface{}{"*"},
+					"allowed_actions": []interface{}{"kibana_all_write"},
+				}},
+			}, flags(true, true)),
+			"kibana_user": with(map[string]interface{}{
+				"description":         "Provide the minimum permissions for a kibana user",
+				"cluster_permissions": []interface{}{"cluster_composite_ops"},
+				"index_permissions": []interface{}{map[string]interface{}{
+					"index_patterns":  []interface{}{".kibana", ".kibana-6", ".kibana_*", ".opensearch_dashboards", ".opensearch_dashboards-6", ".opensearch_dashboards_*"},
+					"fls":             []interface{}{},
+					"masked_fields":   []interface{}{},
+					"allowed_actions": []interface{}{"read", "delete", "manage", "index"},
+				}},
+				"tenant_permissions": []interface{}{},
+			}, flags(true, true)),
+		},
+		"rolesmapping": {
+			"all_access": with(map[string]interface{}{
+				"backend_roles":     []interface{}{"admin"},
+				"and_backend_roles": []interface{}{},
+				"hosts":             []interface{}{},
+				"users":             []interface{}{},
+				"description":       "Maps admin to all_access",
+			}, flags(false, false)),
+		},
+		"tenants": {
+			"global_tenant": with(map[string]interface{}{
+				"description": "Global tenant",
+			}, flags(true, false)),
+		},
+	}
+}
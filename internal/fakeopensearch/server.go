@@ -0,0 +1,478 @@
+// Package fakeopensearch provides an in-process fake of the OpenSearch REST
+// API for tests of the provider that can't rely on a live cluster.
+//
+// The fake keeps all objects in memory and emulates the subset of endpoints
+// the resources and data sources of the provider use: cluster and node info,
+// indices, aliases and documents, index and component templates, data
+// streams, ingest pipelines, stored scripts, snapshot repositories, cluster
+// settings, and the security, ISM, snapshot management, alerting,
+// notifications, anomaly detection and ML plugins. It answers with the
+// response shapes of OpenSearch, including its error responses, but it
+// doesn't validate request bodies beyond what's needed to store and return
+// them.
+package fakeopensearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// DefaultVersion is the OpenSearch version reported by a Server unless
+// WithVersion is given.
+const DefaultVersion = "2.19.0"
+
+// DefaultPlugins are the plugins reported as installed by a Server unless
+// WithPlugins is given.
+var DefaultPlugins = []string{
+	"opensearch-alerting",
+	"opensearch-anomaly-detection",
+	"opensearch-index-management",
+	"opensearch-ml",
+	"opensearch-notifications",
+	"opensearch-security",
+}
+
+// Server is a fake OpenSearch cluster listening on a local address.
+type Server struct {
+	*httptest.Server
+
+	version   string
+	plugins   []string
+	forbidden []string
+
+	mu                 sync.Mutex
+	seqNo              int
+	nextID             int
+	clusterSettings    map[string]interface{}
+	indices            map[string]*index
+	indexTemplates     map[string]map[string]interface{}
+	componentTemplates map[string]map[string]interface{}
+	dataStreams        map[string]bool
+	pipelines          map[string]map[string]interface{}
+	scripts            map[string]map[string]interface{}
+	repositories       map[string]map[string]interface{}
+	security           map[string]map[string]map[string]interface{}
+	auditConfig        map[string]interface{}
+	ismPolicies        map[string]*document
+	smPolicies         map[string]*document
+	monitors           map[string]*document
+	detectors          map[string]*document
+	channels           map[string]*document
+	mlConnectors       map[string]map[string]interface{}
+	mlModelGroups      map[string]map[string]interface{}
+	mlModels           map[string]map[string]interface{}
+	mlTasks            map[string]map[string]interface{}
+
+	// number of requests served by method and path, see Requests
+	requests map[string]int
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithVersion sets the OpenSearch version reported by the server.
+func WithVersion(version string) Option {
+	return func(s *Server) {
+		s.version = version
+	}
+}
+
+// WithPlugins sets the plugins reported as installed by the server.
+func WithPlugins(plugins ...string) Option {
+	return func(s *Server) {
+		s.plugins = plugins
+	}
+}
+
+// WithForbidden makes the server answer the requests of paths with a 403
+// security_exception, as to a user lacking the permission.
+func WithForbidden(paths ...string) Option {
+	return func(s *Server) {
+		s.forbidden = append(s.forbidden, paths...)
+	}
+}
+
+// New starts a Server, which is closed when t and its subtests complete.
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	s := &Server{
+		version:            DefaultVersion,
+		plugins:            DefaultPlugins,
+		clusterSettings:    map[string]interface{}{},
+		indices:            map[string]*index{},
+		indexTemplates:     map[string]map[string]interface{}{},
+		componentTemplates: map[string]map[string]interface{}{},
+		dataStreams:        map[string]bool{},
+		pipelines:          map[string]map[string]interface{}{},
+		scripts:            map[string]map[string]interface{}{},
+		repositories:       map[string]map[string]interface{}{},
+		security:           defaultSecurityObjects(),
+		auditConfig:        defaultAuditConfig(),
+		ismPolicies:        map[string]*document{},
+		smPolicies:         map[string]*document{},
+		monitors:           map[string]*document{},
+		detectors:          map[string]*document{},
+		channels:           map[string]*document{},
+		mlConnectors:       map[string]map[string]interface{}{},
+		mlModelGroups:      map[string]map[string]interface{}{},
+		mlModels:           map[string]map[string]interface{}{},
+		mlTasks:            map[string]map[string]interface{}{},
+		requests:           map[string]int{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Requests returns the number of requests served with method on path, e.g. to
+// check that a request isn't retried.
+func (s *Server) Requests(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+path]
+}
+
+// request is an API call dispatched to the handler of an endpoint.
+type request struct {
+	*http.Request
+	// segments of the unescaped path, without empty ones
+	segments []string
+	body     []byte
+}
+
+// arg returns the path segment at i, or "" if the path is shorter.
+func (r *request) arg(i int) string {
+	if i < len(r.segments) {
+		return r.segments[i]
+	}
+	return ""
+}
+
+// decode unmarshals the body of the request into v, and writes a parse error
+// if it isn't valid JSON.
+func (r *request) decode(w http.ResponseWriter, v interface{}) bool {
+	if len(r.body) == 0 {
+		writeError(w, http.StatusBadRequest, "parse_exception", "request body is required")
+		return false
+	}
+	if err := json.Unmarshal(r.body, v); err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", fmt.Sprintf("failed to parse request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, httpReq *http.Request) {
+	body, err := io.ReadAll(httpReq.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse_exception", err.Error())
+		return
+	}
+
+	// Segments are split before unescaping, date math index names may
+	// contain an escaped slash.
+	r := &request{Request: httpReq, body: body}
+	for _, segment := range strings.Split(httpReq.URL.EscapedPath(), "/") {
+		if segment == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		r.segments = append(r.segments, segment)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[httpReq.Method+" "+httpReq.URL.Path]++
+
+	for _, path := range s.forbidden {
+		if httpReq.URL.Path == path {
+			writeError(w, http.StatusForbidden, "security_exception", fmt.Sprintf("no permissions for [%s %s]", httpReq.Method, path))
+			return
+		}
+	}
+
+	switch r.arg(0) {
+	case "":
+		s.handleRoot(w, r)
+	case "_cat":
+		s.handleCat(w, r)
+	case "_cluster":
+		s.handleCluster(w, r)
+	case "_nodes":
+		s.handleNodes(w, r)
+	case "_index_template":
+		if r.arg(1) == "_simulate_index" {
+			s.simulateIndex(w, r)
+			return
+		}
+		s.handleTemplate(w, r, s.indexTemplates, "index_templates", "index_template")
+	case "_component_template":
+		s.handleTemplate(w, r, s.componentTemplates, "component_templates", "component_template")
+	case "_data_stream":
+		s.handleDataStream(w, r)
+	case "_ingest":
+		s.handlePipeline(w, r)
+	case "_scripts":
+		s.handleScript(w, r)
+	case "_snapshot":
+		s.handleRepository(w, r)
+	case "_plugins":
+		s.handlePlugins(w, r)
+	default:
+		if strings.HasPrefix(r.arg(0), "_") {
+			writeUnsupported(w, r)
+			return
+		}
+		s.handleIndex(w, r)
+	}
+}
+
+func (s *Server) handlePlugins(w http.ResponseWriter, r *request) {
+	switch r.arg(1) {
+	case "_security":
+		s.handleSecurity(w, r)
+	case "_ism":
+		s.handleISM(w, r)
+	case "_sm":
+		s.handleSM(w, r)
+	case "_alerting":
+		s.handleMonitor(w, r)
+	case "_notifications":
+		s.handleChannel(w, r)
+	case "_anomaly_detection":
+		s.handleDetector(w, r)
+	case "_ml":
+		s.handleML(w, r)
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+func (s *Server) handleRoot(w http.ResponseWriter, r *request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeUnsupported(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":         "fake-node",
+		"cluster_name": "fake-cluster",
+		"cluster_uuid": "fake-cluster-uuid",
+		"version": map[string]interface{}{
+			"distribution":                        "opensearch",
+			"number":                              s.version,
+			"build_type":                          "tar",
+			"lucene_version":                      "9.12.1",
+			"minimum_wire_compatibility_version":  "7.10.0",
+			"minimum_index_compatibility_version": "7.0.0",
+		},
+		"tagline": "The OpenSearch Project: https://opensearch.org/",
+	})
+}
+
+func (s *Server) handleCat(w http.ResponseWriter, r *request) {
+	switch {
+	case r.Method == http.MethodGet && r.arg(1) == "plugins":
+		var rows []map[string]string
+		for _, plugin := range s.plugins {
+			rows = append(rows, map[string]string{"name": "fake-node", "component": plugin, "version": s.version})
+		}
+		writeJSON(w, http.StatusOK, rows)
+	case r.Method == http.MethodGet && r.arg(1) == "indices":
+		s.catIndices(w, r)
+	case r.Method == http.MethodGet && r.arg(1) == "aliases":
+		s.catAliases(w, r)
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// handleNodes serves the info of the single node of the cluster, which hosts
+// all the plugins.
+func (s *Server) handleNodes(w http.ResponseWriter, r *request) {
+	if r.Method != http.MethodGet || r.arg(1) != "" {
+		writeUnsupported(w, r)
+		return
+	}
+	var plugins []map[string]interface{}
+	for _, plugin := range s.plugins {
+		plugins = append(plugins, map[string]interface{}{"name": plugin, "version": s.version})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"_nodes":       map[string]interface{}{"total": 1, "successful": 1, "failed": 0},
+		"cluster_name": "fake-cluster",
+		"nodes": map[string]interface{}{
+			"fake-node-id": map[string]interface{}{
+				"name":              "fake-node",
+				"transport_address": "127.0.0.1:9300",
+				"host":              "127.0.0.1",
+				"ip":                "127.0.0.1",
+				"version":           s.version,
+				"build_type":        "tar",
+				"roles":             []string{"cluster_manager", "data", "ingest", "remote_cluster_client"},
+				"attributes":        map[string]string{"shard_indexing_pressure_enabled": "true"},
+				"plugins":           plugins,
+			},
+		},
+	})
+}
+
+func (s *Server) handleCluster(w http.ResponseWriter, r *request) {
+	switch {
+	case r.arg(1) == "health" && r.Method == http.MethodGet:
+		s.clusterHealth(w, r)
+	case r.arg(1) == "settings" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"persistent": s.clusterSettings,
+			"transient":  map[string]interface{}{},
+		})
+	case r.arg(1) == "settings" && r.Method == http.MethodPut:
+		var body struct {
+			Persistent map[string]interface{} `json:"persistent"`
+			Transient  map[string]interface{} `json:"transient"`
+		}
+		if !r.decode(w, &body) {
+			return
+		}
+		updated := map[string]interface{}{}
+		flattenSettings("", body.Persistent, updated)
+		for key, value := range updated {
+			if value != nil {
+				s.clusterSettings[key] = value
+				continue
+			}
+			// A null value resets a setting, or all the settings matching
+			// a wildcard.
+			for existing := range s.clusterSettings {
+				if matchPattern(key, existing) {
+					delete(s.clusterSettings, existing)
+				}
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"acknowledged": true,
+			"persistent":   updated,
+			"transient":    map[string]interface{}{},
+		})
+	default:
+		writeUnsupported(w, r)
+	}
+}
+
+// healthRanks orders the statuses of the health of a cluster.
+var healthRanks = map[string]int{"red": 0, "yellow": 1, "green": 2}
+
+// clusterHealth serves the health of the indices matched by the path, or of
+// all of them. Since the cluster has a single node, replicas are never
+// assigned and an index with replicas is yellow. Waiting for a status the
+// indices don't have times out immediately.
+func (s *Server) clusterHealth(w http.ResponseWriter, r *request) {
+	names := sortedKeys(s.indices)
+	missing := ""
+	if r.arg(2) != "" {
+		names, missing = s.resolveIndices(r.arg(2))
+	}
+
+	status := "green"
+	primaries, unassigned := 0, 0
+	for _, name := range names {
+		settings := s.indices[name].settings
+		shards, _ := strconv.Atoi(fmt.Sprint(settings["index.number_of_shards"]))
+		replicas, _ := strconv.Atoi(fmt.Sprint(settings["index.number_of_replicas"]))
+		primaries += shards
+		unassigned += shards * replicas
+	}
+	if unassigned > 0 {
+		status = "yellow"
+	}
+	if missing != "" {
+		status = "red"
+	}
+
+	query := r.URL.Query()
+	timedOut := false
+	if waitFor := query.Get("wait_for_status"); waitFor != "" && healthRanks[status] < healthRanks[waitFor] {
+		timedOut = true
+	}
+	switch waitFor := query.Get("wait_for_active_shards"); waitFor {
+	case "", "0":
+	case "all":
+		timedOut = timedOut || unassigned > 0
+	default:
+		if n, err := strconv.Atoi(waitFor); err == nil && n > primaries {
+			timedOut = true
+		}
+	}
+
+	code := http.StatusOK
+	if timedOut {
+		code = http.StatusRequestTimeout
+	}
+	percent := 100.0
+	if primaries+unassigned > 0 {
+		percent = 100 * float64(primaries) / float64(primaries+unassigned)
+	}
+	writeJSON(w, code, map[string]interface{}{
+		"cluster_name":                     "fake-cluster",
+		"status":                           status,
+		"timed_out":                        timedOut,
+		"number_of_nodes":                  1,
+		"number_of_data_nodes":             1,
+		"discovered_master":                true,
+		"discovered_cluster_manager":       true,
+		"active_primary_shards":            primaries,
+		"active_shards":                    primaries,
+		"relocating_shards":                0,
+		"initializing_shards":              0,
+		"unassigned_shards":                unassigned,
+		"delayed_unassigned_shards":        0,
+		"number_of_pending_tasks":          0,
+		"number_of_in_flight_fetch":        0,
+		"task_max_waiting_in_queue_millis": 0,
+		"active_shards_percent_as_number":  percent,
+	})
+}
+
+// newID returns a new identifier for an object created without one.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("fake%016d", s.nextID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of OpenSearch.
+func writeError(w http.ResponseWriter, status int, errorType, reason string) {
+	cause := map[string]interface{}{"type": errorType, "reason": reason}
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"root_cause": []interface{}{cause},
+			"type":       errorType,
+			"reason":     reason,
+		},
+		"status": status,
+	})
+}
+
+func writeUnsupported(w http.ResponseWriter, r *request) {
+	writeError(w, http.StatusBadRequest, "illegal_argument_exception",
+		fmt.Sprintf("no handler found for uri [%s] and method [%s]", r.URL.Path, r.Method))
+}
@@ -0,0 +1,248 @@
+package fakeopensearch
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// call sends a request with the JSON body to s and returns the status and
+// the decoded response.
+func call(t *testing.T, s *Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var decoded map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&decoded); err != nil {
+		t.Fatalf("%s %s: decoding response: %s", method, path, err)
+	}
+	return res.StatusCode, decoded
+}
+
+// errorType returns the type of the error of an OpenSearch error response.
+func errorType(res map[string]interface{}) string {
+	if e, ok := res["error"].(map[string]interface{}); ok {
+		t, _ := e["type"].(string)
+		return t
+	}
+	return ""
+}
+
+func TestRoot(t *testing.T) {
+	s := New(t, WithVersion("2.11.0"))
+
+	status, res := call(t, s, http.MethodGet, "/", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if number := res["version"].(map[string]interface{})["number"]; number != "2.11.0" {
+		t.Errorf("expected version 2.11.0, got %v", number)
+	}
+}
+
+func TestUnsupportedEndpoint(t *testing.T) {
+	s := New(t)
+
+	status, res := call(t, s, http.MethodGet, "/_tasks", "")
+	if status != http.StatusBadRequest || errorType(res) != "illegal_argument_exception" {
+		t.Errorf("expected an illegal_argument_exception, got %d %v", status, res)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	s := New(t)
+
+	status, _ := call(t, s, http.MethodPut, "/test", `{"settings": {"index": {"number_of_replicas": 2}}}`)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 creating the index, got %d", status)
+	}
+	status, res := call(t, s, http.MethodPut, "/test", `{}`)
+	if status != http.StatusBadRequest || errorType(res) != "resource_already_exists_exception" {
+		t.Errorf("expected a resource_already_exists_exception, got %d %v", status, res)
+	}
+
+	status, res = call(t, s, http.MethodGet, "/test", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 getting the index, got %d", status)
+	}
+	settings := res["test"].(map[string]interface{})["settings"].(map[string]interface{})["index"].(map[string]interface{})
+	if replicas := settings["number_of_replicas"]; replicas != "2" {
+		t.Errorf("expected 2 replicas, got %v", replicas)
+	}
+
+	if status, _ := call(t, s, http.MethodDelete, "/test", ""); status != http.StatusOK {
+		t.Errorf("expected status 200 deleting the index, got %d", status)
+	}
+	status, res = call(t, s, http.MethodGet, "/test", "")
+	if status != http.StatusNotFound || errorType(res) != "index_not_found_exception" {
+		t.Errorf("expected an index_not_found_exception, got %d %v", status, res)
+	}
+}
+
+func TestSimulateIndex(t *testing.T) {
+	s := New(t)
+
+	call(t, s, http.MethodPut, "/_component_template/mappings", `{"template": {"mappings": {"properties": {"message": {"type": "text"}}}}}`)
+	call(t, s, http.MethodPut, "/_index_template/logs", `{"index_patterns": ["logs-*"], "priority": 10, "composed_of": ["mappings"], "template": {"settings": {"number_of_shards": 2}, "aliases": {"logs": {}}}}`)
+	call(t, s, http.MethodPut, "/_index_template/all", `{"index_patterns": ["*"], "template": {"settings": {"number_of_shards": 1}}}`)
+
+	status, res := call(t, s, http.MethodPost, "/_index_template/_simulate_index/logs-1", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 simulating the index, got %d %v", status, res)
+	}
+	template := res["template"].(map[string]interface{})
+	if shards := template["settings"].(map[string]interface{})["index"].(map[string]interface{})["number_of_shards"]; shards != "2" {
+		t.Errorf("expected the settings of the template with the highest priority, got %v", shards)
+	}
+	if _, ok := template["mappings"].(map[string]interface{})["properties"]; !ok {
+		t.Errorf("expected the mappings of the component template, got %v", template["mappings"])
+	}
+	if overlapping := res["overlapping"].([]interface{}); len(overlapping) != 1 || overlapping[0].(map[string]interface{})["name"] != "all" {
+		t.Errorf("expected the template all to overlap, got %v", overlapping)
+	}
+
+	call(t, s, http.MethodDelete, "/_index_template/all", "")
+	if _, res := call(t, s, http.MethodPost, "/_index_template/_simulate_index/metrics-1", ""); len(res) != 0 {
+		t.Errorf("expected an empty response without a matching template, got %v", res)
+	}
+}
+
+func TestSecurityObject(t *testing.T) {
+	s := New(t)
+
+	status, _ := call(t, s, http.MethodPut, "/_plugins/_security/api/internalusers/jdoe", `{"password": "secret", "backend_roles": ["admin"]}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the user, got %d", status)
+	}
+	status, _ = call(t, s, http.MethodPut, "/_plugins/_security/api/internalusers/jdoe", `{"password": "secret", "backend_roles": []}`)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 updating the user, got %d", status)
+	}
+
+	_, res := call(t, s, http.MethodGet, "/_plugins/_security/api/internalusers/jdoe", "")
+	user := res["jdoe"].(map[string]interface{})
+	if _, ok := user["password"]; ok {
+		t.Error("expected the password not to be returned")
+	}
+	if roles := user["backend_roles"].([]interface{}); len(roles) != 0 {
+		t.Errorf("expected the backend roles to be updated, got %v", roles)
+	}
+
+	if status, _ := call(t, s, http.MethodDelete, "/_plugins/_security/api/internalusers/jdoe", ""); status != http.StatusOK {
+		t.Errorf("expected status 200 deleting the user, got %d", status)
+	}
+	status, res = call(t, s, http.MethodGet, "/_plugins/_security/api/internalusers/jdoe", "")
+	if status != http.StatusNotFound || res["status"] != "NOT_FOUND" {
+		t.Errorf("expected a missing user, got %d %v", status, res)
+	}
+}
+
+func TestReservedSecurityObject(t *testing.T) {
+	s := New(t)
+
+	status, res := call(t, s, http.MethodGet, "/_plugins/_security/api/roles/all_access", "")
+	if status != http.StatusOK || res["all_access"].(map[string]interface{})["reserved"] != true {
+		t.Fatalf("expected the built-in all_access role to be reserved, got %d %v", status, res)
+	}
+
+	status, res = call(t, s, http.MethodPut, "/_plugins/_security/api/roles/all_access", `{"cluster_permissions": []}`)
+	if status != http.StatusForbidden || res["status"] != "FORBIDDEN" {
+		t.Errorf("expected updating a reserved role to be forbidden, got %d %v", status, res)
+	}
+	if status, _ := call(t, s, http.MethodDelete, "/_plugins/_security/api/tenants/global_tenant", ""); status != http.StatusForbidden {
+		t.Errorf("expected deleting a reserved tenant to be forbidden, got %d", status)
+	}
+}
+
+func TestISMPolicyVersioning(t *testing.T) {
+	s := New(t)
+	policy := `{"policy": {"description": "test", "default_state": "hot", "states": []}}`
+
+	status, res := call(t, s, http.MethodPut, "/_plugins/_ism/policies/test", policy)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the policy, got %d", status)
+	}
+	seqNo := res["_seq_no"].(float64)
+
+	status, _ = call(t, s, http.MethodPut, "/_plugins/_ism/policies/test", policy)
+	if status != http.StatusConflict {
+		t.Errorf("expected status 409 updating the policy without a sequence number, got %d", status)
+	}
+	status, _ = call(t, s, http.MethodPut, "/_plugins/_ism/policies/test?if_seq_no=100&if_primary_term=1", policy)
+	if status != http.StatusConflict {
+		t.Errorf("expected status 409 updating the policy with a stale sequence number, got %d", status)
+	}
+	status, res = call(t, s, http.MethodPut, "/_plugins/_ism/policies/test?if_primary_term=1&if_seq_no="+strconv.Itoa(int(seqNo)), policy)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 updating the policy, got %d %v", status, res)
+	}
+	if version := res["_version"]; version != float64(2) {
+		t.Errorf("expected version 2, got %v", version)
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	s := New(t)
+
+	status, res := call(t, s, http.MethodPost, "/_plugins/_alerting/monitors", `{"name": "test", "enabled": true}`)
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201 creating the monitor, got %d", status)
+	}
+	id := res["_id"].(string)
+
+	_, res = call(t, s, http.MethodGet, "/_plugins/_alerting/monitors/"+id, "")
+	if name := res["monitor"].(map[string]interface{})["name"]; name != "test" {
+		t.Errorf("expected monitor test, got %v", name)
+	}
+
+	if status, _ := call(t, s, http.MethodDelete, "/_plugins/_alerting/monitors/"+id, ""); status != http.StatusOK {
+		t.Errorf("expected status 200 deleting the monitor, got %d", status)
+	}
+	status, res = call(t, s, http.MethodGet, "/_plugins/_alerting/monitors/"+id, "")
+	if status != http.StatusNotFound || errorType(res) != "status_exception" {
+		t.Errorf("expected a missing monitor, got %d %v", status, res)
+	}
+}
+
+func TestMLModelDeployment(t *testing.T) {
+	s := New(t)
+
+	_, res := call(t, s, http.MethodPost, "/_plugins/_ml/model_groups/_register", `{"name": "test"}`)
+	groupID := res["model_group_id"].(string)
+	status, res := call(t, s, http.MethodPost, "/_plugins/_ml/models/_register", `{"name": "test", "function_name": "remote", "model_group_id": "`+groupID+`", "connector_id": "test"}`)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200 registering the model, got %d %v", status, res)
+	}
+	path := "/_plugins/_ml/models/" + res["model_id"].(string)
+
+	if status, _ := call(t, s, http.MethodPost, path+"/_predict", `{"parameters": {}}`); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 predicting with an undeployed model, got %d", status)
+	}
+	call(t, s, http.MethodPost, path+"/_deploy", "")
+	if status, _ := call(t, s, http.MethodPost, path+"/_predict", `{"parameters": {}}`); status != http.StatusOK {
+		t.Errorf("expected status 200 predicting with a deployed model, got %d", status)
+	}
+	if status, _ := call(t, s, http.MethodDelete, path, ""); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 deleting a deployed model, got %d", status)
+	}
+
+	call(t, s, http.MethodPost, path+"/_undeploy", "")
+	if status, _ := call(t, s, http.MethodDelete, path, ""); status != http.StatusOK {
+		t.Errorf("expected status 200 deleting an undeployed model, got %d", status)
+	}
+	if status, _ := call(t, s, http.MethodGet, path, ""); status != http.StatusNotFound {
+		t.Errorf("expected status 404 getting a deleted model, got %d", status)
+	}
+}
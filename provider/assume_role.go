@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsstscreds "github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	awssts "github.com/aws/aws-sdk-go-v2/service/sts"
+	awssststypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// assumeRoleConfig is a hop of the chain of roles assumed before signing
+// requests, each one assumed with the credentials of the previous one.
+type assumeRoleConfig struct {
+	arn               string
+	externalID        string
+	sessionName       string
+	duration          time.Duration
+	tags              map[string]string
+	transitiveTagKeys []string
+	policy            string
+}
+
+func expandAssumeRoleConfigs(raw []interface{}) ([]assumeRoleConfig, error) {
+	roles := make([]assumeRoleConfig, 0, len(raw))
+	for i, r := range raw {
+		if r == nil {
+			continue
+		}
+		m := r.(map[string]interface{})
+
+		role := assumeRoleConfig{
+			arn:         m["arn"].(string),
+			externalID:  m["external_id"].(string),
+			sessionName: m["session_name"].(string),
+			policy:      m["policy"].(string),
+		}
+		if d := m["duration"].(string); d != "" {
+			duration, err := time.ParseDuration(d)
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration of assume_role %d: %w", i, err)
+			}
+			role.duration = duration
+		}
+		if tags := m["tags"].(map[string]interface{}); len(tags) > 0 {
+			role.tags = make(map[string]string, len(tags))
+			for k, v := range tags {
+				role.tags[k] = v.(string)
+			}
+		}
+		for _, k := range m["transitive_tag_keys"].(*schema.Set).List() {
+			role.transitiveTagKeys = append(role.transitiveTagKeys, k.(string))
+		}
+		sort.Strings(role.transitiveTagKeys)
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// awsAssumeRoles returns the roles to assume in order: those of the
+// assume_role blocks, or the one of aws_assume_role_arn.
+func (conf *ProviderConf) awsAssumeRoles() []assumeRoleConfig {
+	if len(conf.awsAssumeRoleChain) > 0 {
+		return conf.awsAssumeRoleChain
+	}
+	if conf.awsAssumeRoleArn != "" {
+		return []assumeRoleConfig{{
+			arn:        conf.awsAssumeRoleArn,
+			externalID: conf.awsAssumeRoleExternalID,
+		}}
+	}
+	return nil
+}
+
+// assumeRoleCredentials assumes role with the credentials of cfg. The session
+// name of the role defaults to defaultSessionName.
+func assumeRoleCredentials(cfg aws.Config, role assumeRoleConfig, defaultSessionName string) aws.CredentialsProvider {
+	provider := awsstscreds.NewAssumeRoleProvider(awssts.NewFromConfig(cfg), role.arn, func(o *awsstscreds.AssumeRoleOptions) {
+		if role.externalID != "" {
+			o.ExternalID = aws.String(role.externalID)
+		}
+
+		o.RoleSessionName = role.sessionName
+		if o.RoleSessionName == "" {
+			o.RoleSessionName = defaultSessionName
+		}
+
+		if role.duration != 0 {
+			o.Duration = role.duration
+		}
+
+		if role.policy != "" {
+			o.Policy = aws.String(role.policy)
+		}
+
+		keys := make([]string, 0, len(role.tags))
+		for k := range role.tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o.Tags = append(o.Tags, awssststypes.Tag{Key: aws.String(k), Value: aws.String(role.tags[k])})
+		}
+		o.TransitiveTagKeys = role.transitiveTagKeys
+	})
+
+	return aws.NewCredentialsCache(provider)
+}
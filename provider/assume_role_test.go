@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandAssumeRoleConfigs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"assume_role": []interface{}{
+			map[string]interface{}{
+				"arn": "arn:aws:iam::111111111111:role/ci",
+			},
+			map[string]interface{}{
+				"arn":                 "arn:aws:iam::222222222222:role/landing-zone",
+				"session_name":        "terraform",
+				"duration":            "1h",
+				"tags":                map[string]interface{}{"team": "search"},
+				"transitive_tag_keys": []interface{}{"team"},
+			},
+		},
+	})
+
+	roles, err := expandAssumeRoleConfigs(d.Get("assume_role").([]interface{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []assumeRoleConfig{
+		{arn: "arn:aws:iam::111111111111:role/ci"},
+		{
+			arn:               "arn:aws:iam::222222222222:role/landing-zone",
+			sessionName:       "terraform",
+			duration:          time.Hour,
+			tags:              map[string]string{"team": "search"},
+			transitiveTagKeys: []string{"team"},
+		},
+	}
+	if !reflect.DeepEqual(roles, want) {
+		t.Errorf("got %+v, want %+v", roles, want)
+	}
+}
+
+func TestAWSAssumeRoles(t *testing.T) {
+	legacy := &ProviderConf{awsAssumeRoleArn: "arn:aws:iam::111111111111:role/ci", awsAssumeRoleExternalID: "id"}
+	if got := legacy.awsAssumeRoles(); len(got) != 1 || got[0].arn != legacy.awsAssumeRoleArn || got[0].externalID != "id" {
+		t.Errorf("expected aws_assume_role_arn to be the only role, got %+v", got)
+	}
+
+	if got := (&ProviderConf{}).awsAssumeRoles(); len(got) != 0 {
+		t.Errorf("expected no role, got %+v", got)
+	}
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"hash"
 	"io"
 	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsv4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 )
 
 type awsV4SignerWrapper struct {
@@ -42,3 +47,58 @@ func (client *awsV4SignerWrapper) RoundTrip(request *http.Request) (*http.Respon
 func Wrap(internal http.RoundTripper) http.RoundTripper {
 	return &awsV4SignerWrapper{internal: internal}
 }
+
+// awsSigningTransport signs requests with AWS Signature Version 4. The
+// credentials are retrieved for every request, their provider caching them
+// until they expire.
+type awsSigningTransport struct {
+	rt          http.RoundTripper
+	credentials aws.CredentialsProvider
+	signer      *awsv4.Signer
+	service     string
+	region      string
+	now         func() time.Time
+}
+
+func newAWSSigningTransport(rt http.RoundTripper, credentials aws.CredentialsProvider, service, region string) *awsSigningTransport {
+	return &awsSigningTransport{
+		rt:          rt,
+		credentials: credentials,
+		signer:      awsv4.NewSigner(),
+		service:     service,
+		region:      region,
+		now:         time.Now,
+	}
+}
+
+func (t *awsSigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload []byte
+	if req.Body != nil {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	creds, err := t.credentials.Retrieve(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving AWS credentials: %w", err)
+	}
+
+	signed := req.Clone(req.Context())
+	if req.Body != nil {
+		signed.Body = io.NopCloser(bytes.NewReader(payload))
+		signed.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+	}
+
+	hash := hashPayload(sha256.New(), payload)
+	if err := t.signer.SignHTTP(req.Context(), creds, signed, hash, t.service, t.region, t.now()); err != nil {
+		return nil, fmt.Errorf("error signing request: %w", err)
+	}
+
+	return t.rt.RoundTrip(signed)
+}
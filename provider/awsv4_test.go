@@ -5,8 +5,12 @@ import (
 	"encoding/hex"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
 )
 
 type MockRoundTripper struct {
@@ -55,3 +59,44 @@ func TestRoundTripWithBody(t *testing.T) {
 		t.Fatal("Request with body doesn't contain X-Amz-Content-Sha256 header with correct hash value.")
 	}
 }
+
+func TestAWSSigningTransport(t *testing.T) {
+	var authorization, date, token, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		date = r.Header.Get("X-Amz-Date")
+		token = r.Header.Get("X-Amz-Security-Token")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	defer server.Close()
+
+	credentials := awscredentials.NewStaticCredentialsProvider("AKID", "SECRET", "SESSION")
+	transport := newAWSSigningTransport(http.DefaultTransport, credentials, "es", "us-east-1")
+	transport.now = func() time.Time {
+		return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/test-index", strings.NewReader(`{"settings":{}}`))
+	res, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/20240501/us-east-1/es/aws4_request, SignedHeaders=") {
+		t.Errorf("unexpected Authorization header %q", authorization)
+	}
+	if date != "20240501T120000Z" {
+		t.Errorf("X-Amz-Date: got %q, want %q", date, "20240501T120000Z")
+	}
+	if token != "SESSION" {
+		t.Errorf("X-Amz-Security-Token: got %q, want %q", token, "SESSION")
+	}
+	if body != `{"settings":{}}` {
+		t.Errorf("expected the body to be sent unchanged, got %q", body)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Errorf("expected the original request not to be modified")
+	}
+}
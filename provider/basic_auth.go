@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials from credential_process are refreshed this long before they
+// expire.
+const credentialProcessExpiryDelta = time.Minute
+
+const credentialProcessTimeout = time.Minute
+
+// basicAuthSource provides basic auth credentials read from a password file
+// or returned by an external command, for passwords rotated while the
+// provider runs. They are read on first use and again after the cluster
+// rejected them or, for the command, once they expire.
+type basicAuthSource struct {
+	username     string
+	passwordFile string
+	process      []string
+
+	mu            sync.Mutex
+	authorization string
+	expiry        time.Time
+	now           func() time.Time
+}
+
+// newBasicAuthSource returns a basicAuthSource if conf reads credentials from
+// a file or command, nil otherwise.
+func newBasicAuthSource(conf *ProviderConf) *basicAuthSource {
+	if conf.passwordFile == "" && len(conf.credentialProcess) == 0 {
+		return nil
+	}
+
+	username := conf.username
+	if username == "" && conf.parsedUrl != nil {
+		username = conf.parsedUrl.User.Username()
+	}
+
+	return &basicAuthSource{
+		username:     username,
+		passwordFile: conf.passwordFile,
+		process:      conf.credentialProcess,
+		now:          time.Now,
+	}
+}
+
+func (s *basicAuthSource) Authorization(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authorization != "" && (s.expiry.IsZero() || s.now().Before(s.expiry.Add(-credentialProcessExpiryDelta))) {
+		return s.authorization, nil
+	}
+
+	username, password := s.username, ""
+	s.expiry = time.Time{}
+	if len(s.process) > 0 {
+		creds, err := runCredentialProcess(ctx, s.process)
+		if err != nil {
+			return "", err
+		}
+		if creds.Username != "" {
+			username = creds.Username
+		}
+		password = creds.Password
+		if creds.Expiry != nil {
+			s.expiry = *creds.Expiry
+		}
+	} else {
+		content, isPath, err := readPathOrContent(s.passwordFile)
+		if err != nil {
+			return "", fmt.Errorf("unable to read password_file: %w", err)
+		}
+		if !isPath {
+			return "", fmt.Errorf("password_file %s does not exist", s.passwordFile)
+		}
+		password = strings.TrimRight(content, "\r\n")
+	}
+
+	if username == "" {
+		return "", errors.New("a username is required to authenticate with password_file or credential_process")
+	}
+
+	s.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	return s.authorization, nil
+}
+
+func (s *basicAuthSource) Invalidate(authorization string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.authorization == authorization {
+		s.authorization = ""
+	}
+}
+
+// credentialProcessOutput is the JSON printed on stdout by credential_process.
+type credentialProcessOutput struct {
+	Username string     `json:"username"`
+	Password string     `json:"password"`
+	Expiry   *time.Time `json:"expiry"`
+}
+
+func runCredentialProcess(ctx context.Context, process []string) (*credentialProcessOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, process[0], process[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running credential_process %s: %w: %s", process[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var creds credentialProcessOutput
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return nil, fmt.Errorf("error parsing the output of credential_process %s: %w", process[0], err)
+	}
+	if creds.Password == "" {
+		return nil, fmt.Errorf("credential_process %s returned no password", process[0])
+	}
+
+	log.Printf("[DEBUG] Read credentials from credential_process %s", process[0])
+	return &creds, nil
+}
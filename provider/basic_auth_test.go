@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBasicAuthServer accepts requests authenticated with admin and the
+// current password, and counts the rejected ones.
+func newBasicAuthServer(t *testing.T, password *atomic.Pointer[string]) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var rejected atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != "admin" || p != *password.Load() {
+			rejected.Add(1)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &rejected
+}
+
+func TestBasicAuthSource_RereadsRotatedPasswordFile(t *testing.T) {
+	var password atomic.Pointer[string]
+	current := "initial"
+	password.Store(&current)
+	server, rejected := newBasicAuthServer(t, &password)
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("initial\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := newBasicAuthSource(&ProviderConf{username: "admin", passwordFile: passwordFile})
+	client := &http.Client{Transport: &authTransport{rt: http.DefaultTransport, source: source}}
+
+	get := func() {
+		t.Helper()
+		res, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusOK)
+		}
+	}
+
+	get()
+
+	rotated := "rotated"
+	password.Store(&rotated)
+	if err := os.WriteFile(passwordFile, []byte("rotated\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	get()
+	get()
+
+	if got := rejected.Load(); got != 1 {
+		t.Errorf("expected only the first request after the rotation to be rejected, got %d rejections", got)
+	}
+}
+
+func TestBasicAuthSource_MissingPasswordFile(t *testing.T) {
+	source := newBasicAuthSource(&ProviderConf{username: "admin", passwordFile: filepath.Join(t.TempDir(), "missing")})
+	if _, err := source.Authorization(context.Background()); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected an error for a missing password file, got %v", err)
+	}
+}
+
+func TestBasicAuthSource_CredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "credentials.json")
+	calls := filepath.Join(dir, "calls")
+	writeCredentials := func(password string, expiry time.Time) {
+		t.Helper()
+		content := `{"username":"admin","password":"` + password + `","expiry":"` + expiry.Format(time.RFC3339) + `"}`
+		if err := os.WriteFile(output, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	parsedUrl, _ := url.Parse("https://someone@localhost:9200")
+	source := newBasicAuthSource(&ProviderConf{
+		parsedUrl:         parsedUrl,
+		credentialProcess: []string{"sh", "-c", "echo >> " + calls + " && cat " + output},
+	})
+	now := time.Now()
+	source.now = func() time.Time { return now }
+
+	writeCredentials("first", now.Add(time.Hour))
+	first, err := source.Authorization(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Basic YWRtaW46Zmlyc3Q="; first != want {
+		t.Errorf("expected the username of the command to take precedence, got %q, want %q", first, want)
+	}
+
+	writeCredentials("second", now.Add(2*time.Hour))
+	if cached, _ := source.Authorization(context.Background()); cached != first {
+		t.Errorf("expected the credentials to be cached until they expire")
+	}
+
+	now = now.Add(time.Hour - 30*time.Second)
+	second, err := source.Authorization(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second == first {
+		t.Errorf("expected the command to be run again once the credentials are about to expire")
+	}
+
+	content, _ := os.ReadFile(calls)
+	if got := strings.Count(string(content), "\n"); got != 2 {
+		t.Errorf("expected the command to run twice, got %d", got)
+	}
+}
+
+func TestBasicAuthSource_CredentialProcessFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test command requires a POSIX shell")
+	}
+
+	source := newBasicAuthSource(&ProviderConf{credentialProcess: []string{"sh", "-c", "echo vault is sealed >&2; exit 2"}})
+	_, err := source.Authorization(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "vault is sealed") {
+		t.Errorf("expected the error output of the command to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// capability is a feature of the cluster that a resource or attribute depends
+// on, available from a minimum OpenSearch version and when one of plugins is
+// installed, and possibly not on Amazon OpenSearch Serverless collections.
+type capability struct {
+	// what depends on the capability, used in error messages
+	feature    string
+	minVersion string
+	// alternative names of the plugin providing the capability, e.g. the
+	// OpenSearch and Open Distro ones
+	plugins                 []string
+	unsupportedOnServerless bool
+}
+
+var (
+	capabilityISM = capability{
+		feature:                 "opensearch_ism_policy",
+		plugins:                 []string{"opensearch-index-management", "opendistro-index-management"},
+		unsupportedOnServerless: true,
+	}
+	capabilitySnapshotManagement = capability{
+		feature:                 "opensearch_sm_policy",
+		minVersion:              "2.1.0",
+		plugins:                 []string{"opensearch-index-management"},
+		unsupportedOnServerless: true,
+	}
+	capabilityAlerting = capability{
+		feature:                 "opensearch_monitor",
+		plugins:                 []string{"opensearch-alerting", "opendistro-alerting"},
+		unsupportedOnServerless: true,
+	}
+	capabilityAnomalyDetection = capability{
+		feature:                 "opensearch_anomaly_detection",
+		plugins:                 []string{"opensearch-anomaly-detection", "opendistro-anomaly-detection"},
+		unsupportedOnServerless: true,
+	}
+	capabilityNotifications = capability{
+		feature:                 "opensearch_channel_configuration",
+		minVersion:              "2.0.0",
+		plugins:                 []string{"opensearch-notifications"},
+		unsupportedOnServerless: true,
+	}
+	capabilityMLModelGroups = capability{
+		feature:    "opensearch_ml_model_group",
+		minVersion: "2.8.0",
+		plugins:    []string{"opensearch-ml"},
+	}
+	capabilityMLConnectors = capability{
+		feature:    "opensearch_ml_connector",
+		minVersion: "2.9.0",
+		plugins:    []string{"opensearch-ml"},
+	}
+	capabilityMLModels = capability{
+		feature:    "opensearch_ml_model",
+		minVersion: "2.9.0",
+		plugins:    []string{"opensearch-ml"},
+	}
+	capabilityMLGuardrails = capability{
+		feature:    "`guardrails` of opensearch_ml_model",
+		minVersion: "2.13.0",
+		plugins:    []string{"opensearch-ml"},
+	}
+
+	capabilityClusterSettings  = unsupportedOnServerless("opensearch_cluster_settings")
+	capabilityISMPolicyMapping = unsupportedOnServerless("opensearch_ism_policy_mapping")
+	capabilitySnapshots        = unsupportedOnServerless("opensearch_snapshot_repository")
+	capabilitySecurityRoles    = unsupportedOnServerless("opensearch_role")
+	capabilitySecurityMappings = unsupportedOnServerless("opensearch_roles_mapping")
+	capabilitySecurityUsers    = unsupportedOnServerless("opensearch_user")
+	capabilitySecurityAudit    = unsupportedOnServerless("opensearch_audit_config")
+	capabilitySecurityTenants  = unsupportedOnServerless("opensearch_dashboard_tenant")
+)
+
+// requireCapabilities returns a CustomizeDiff function failing the plan when
+// the cluster lacks the capability needed to create the resource, or one
+// needed by an attribute set in the configuration. Existing resources and
+// unchanged attributes aren't checked again.
+func requireCapabilities(resource capability, attributes map[string]capability) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		conf, ok := meta.(*ProviderConf)
+		if !ok || conf == nil {
+			return nil
+		}
+
+		if d.Id() == "" {
+			if err := conf.checkCapability(ctx, resource); err != nil {
+				return err
+			}
+		}
+		for attribute, c := range attributes {
+			if _, ok := d.GetOk(attribute); !ok || !d.HasChange(attribute) {
+				continue
+			}
+			if err := conf.checkCapability(ctx, c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// checkCapability returns an error naming the minimum version or the missing
+// plugin if the cluster lacks c. If the version or the installed plugins can't
+// be determined, the check is skipped, leaving the request to fail.
+func (conf *ProviderConf) checkCapability(ctx context.Context, c capability) error {
+	if conf.isServerless() {
+		if c.unsupportedOnServerless {
+			return fmt.Errorf("%s is not supported on Amazon OpenSearch Serverless collections", c.feature)
+		}
+		// The version of a collection is a placeholder and its plugins
+		// can't be listed.
+		return nil
+	}
+	if c.minVersion == "" && len(c.plugins) == 0 {
+		return nil
+	}
+
+	client, err := getClient(conf)
+	if err != nil {
+		log.Printf("[WARN] Unable to check that the cluster supports %s: %s", c.feature, err)
+		return nil
+	}
+
+	// Only OpenSearch versions are comparable, not those of Elasticsearch
+	// clusters with Open Distro plugins.
+	if c.minVersion != "" && conf.flavor == OpenSearch {
+		current, err := version.NewVersion(conf.osVersion)
+		if err != nil {
+			log.Printf("[WARN] Unable to parse the cluster version %q: %s", conf.osVersion, err)
+		} else if current.LessThan(version.Must(version.NewVersion(c.minVersion))) {
+			return fmt.Errorf("%s requires OpenSearch %s or later, the cluster runs %s", c.feature, c.minVersion, conf.osVersion)
+		}
+	}
+
+	if len(c.plugins) > 0 {
+		plugins, ok := conf.installedPlugins(ctx, client)
+		if ok && !containsAnyString(plugins, c.plugins) {
+			return fmt.Errorf("%s requires the %s plugin, which is not installed on the cluster", c.feature, c.plugins[0])
+		}
+	}
+
+	return nil
+}
+
+// installedPlugins returns the plugins installed on any node of the cluster,
+// listed once and then cached for the lifetime of the provider. ok is false
+// if they can't be listed, e.g. on Amazon OpenSearch Serverless or without
+// the permission to.
+func (conf *ProviderConf) installedPlugins(ctx context.Context, client *elastic7.Client) (plugins []string, ok bool) {
+	conf.pluginsMu.Lock()
+	defer conf.pluginsMu.Unlock()
+
+	if conf.plugins != nil {
+		return *conf.plugins, conf.pluginsKnown
+	}
+
+	res, err := client.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/_cat/plugins",
+		Params: url.Values{"format": []string{"json"}},
+	})
+	plugins, err = parseCatPlugins(res, err)
+	if err != nil {
+		log.Printf("[WARN] Unable to list the plugins installed on the cluster: %s", err)
+	}
+	conf.plugins = &plugins
+	conf.pluginsKnown = err == nil
+
+	return plugins, conf.pluginsKnown
+}
+
+func parseCatPlugins(res *elastic7.Response, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []struct {
+		Component string `json:"component"`
+	}
+	if err := json.Unmarshal(res.Body, &nodes); err != nil {
+		return nil, fmt.Errorf("error unmarshalling _cat/plugins: %w", err)
+	}
+
+	var plugins []string
+	for _, n := range nodes {
+		if !containsString(plugins, n.Component) {
+			plugins = append(plugins, n.Component)
+		}
+	}
+	return plugins, nil
+}
+
+func containsAnyString(h []string, needles []string) bool {
+	for _, n := range needles {
+		if containsString(h, n) {
+			return true
+		}
+	}
+	return false
+}
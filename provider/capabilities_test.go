@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeCapabilitiesCalls counts the requests of the version and of the
+// plugins of the cluster.
+type fakeCapabilitiesCalls struct {
+	root, catPlugins atomic.Int32
+}
+
+// newFakeCapabilitiesServer serves the version of the cluster and the
+// plugins installed on it. A nil plugins list makes _cat/plugins forbidden.
+func newFakeCapabilitiesServer(t *testing.T, buildFlavor, versionNumber string, plugins []string) (*ProviderConf, *fakeCapabilitiesCalls) {
+	t.Helper()
+	calls := &fakeCapabilitiesCalls{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/":
+			calls.root.Add(1)
+			fmt.Fprintf(w, `{"version":{"number":%q,"build_flavor":%q}}`, versionNumber, buildFlavor)
+		case "/_cat/plugins":
+			calls.catPlugins.Add(1)
+			if plugins == nil {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":{"type":"security_exception","reason":"no permissions"},"status":403}`))
+				return
+			}
+			var nodes []string
+			for _, p := range plugins {
+				nodes = append(nodes, fmt.Sprintf(`{"name":"node-1","component":%q,"version":%q},{"name":"node-2","component":%q,"version":%q}`, p, versionNumber, p, versionNumber))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(nodes, ","))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	parsedUrl, _ := url.Parse(server.URL)
+	return &ProviderConf{
+		rawUrl:             server.URL,
+		parsedUrl:          parsedUrl,
+		pingTimeoutSeconds: 5,
+	}, calls
+}
+
+func TestCheckCapability(t *testing.T) {
+	cases := []struct {
+		name        string
+		buildFlavor string
+		version     string
+		plugins     []string
+		capability  capability
+		wantErr     string
+	}{
+		{
+			name:       "supported",
+			version:    "2.13.0",
+			plugins:    []string{"opensearch-ml", "opensearch-security"},
+			capability: capabilityMLGuardrails,
+		},
+		{
+			name:       "version too old",
+			version:    "2.11.1",
+			plugins:    []string{"opensearch-ml"},
+			capability: capabilityMLGuardrails,
+			wantErr:    "`guardrails` of opensearch_ml_model requires OpenSearch 2.13.0 or later, the cluster runs 2.11.1",
+		},
+		{
+			name:       "plugin missing",
+			version:    "2.11.0",
+			plugins:    []string{"opensearch-security"},
+			capability: capabilitySnapshotManagement,
+			wantErr:    "opensearch_sm_policy requires the opensearch-index-management plugin, which is not installed on the cluster",
+		},
+		{
+			name:       "plugins can't be listed",
+			version:    "2.11.0",
+			capability: capabilitySnapshotManagement,
+		},
+		{
+			name:        "Open Distro plugin",
+			buildFlavor: "oss",
+			version:     "7.10.2",
+			plugins:     []string{"opendistro-anomaly-detection"},
+			capability:  capabilityAnomalyDetection,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, _ := newFakeCapabilitiesServer(t, tc.buildFlavor, tc.version, tc.plugins)
+
+			err := conf.checkCapability(context.Background(), tc.capability)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckCapabilityIgnoresVersionOfElasticsearch(t *testing.T) {
+	// An Elasticsearch 7.10 cluster with Open Distro plugins reports the
+	// default build flavor. Its version can't be compared to OpenSearch ones.
+	conf, _ := newFakeCapabilitiesServer(t, "default", "7.10.2", []string{"opendistro-index-management"})
+
+	err := conf.checkCapability(context.Background(), capability{feature: "test", minVersion: "8.0.0"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestInstalledPluginsAreListedOnce(t *testing.T) {
+	conf, calls := newFakeCapabilitiesServer(t, "", "2.11.0", []string{"opensearch-ml", "opensearch-alerting"})
+
+	for _, c := range []capability{capabilityMLModels, capabilityAlerting, capabilityNotifications} {
+		_ = conf.checkCapability(context.Background(), c)
+	}
+
+	if got := calls.catPlugins.Load(); got != 1 {
+		t.Errorf("expected _cat/plugins to be requested once, got %d", got)
+	}
+	if got := calls.root.Load(); got != 1 {
+		t.Errorf("expected the version to be requested once, got %d", got)
+	}
+	plugins, ok := conf.installedPlugins(context.Background(), nil)
+	if !ok || len(plugins) != 2 {
+		t.Errorf("expected 2 distinct plugins, got %v (%t)", plugins, ok)
+	}
+}
+
+func TestRequireCapabilitiesWithoutProviderConf(t *testing.T) {
+	customizeDiff := requireCapabilities(capabilityISM, nil)
+
+	for _, meta := range []interface{}{nil, (*ProviderConf)(nil), "not a provider configuration"} {
+		if err := customizeDiff(context.Background(), nil, meta); err != nil {
+			t.Errorf("expected the check to be skipped for %#v, got %v", meta, err)
+		}
+	}
+}
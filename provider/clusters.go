@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Attributes of the provider that can be set for each of its clusters. The
+// other settings, e.g. `retry` or `http`, are those of the provider for
+// every cluster.
+var clusterConnectionAttributes = []string{
+	"url",
+	"urls",
+	"username",
+	"password",
+	"password_file",
+	"credential_process",
+	"token",
+	"token_name",
+	"oidc",
+	"headers",
+	"insecure",
+	"cacert_file",
+	"client_cert_path",
+	"client_key_path",
+	"tls_min_version",
+	"host_override",
+	"proxy",
+	"opensearch_version",
+	"sign_aws_requests",
+	"aws_signature_service",
+	"aws_region",
+	"aws_profile",
+	"aws_access_key",
+	"aws_secret_key",
+	"aws_token",
+	"aws_assume_role_arn",
+	"aws_assume_role_external_id",
+	"aws_assume_role_session_name",
+	"assume_role",
+	"aws_web_identity_role_arn",
+	"aws_web_identity_token_file",
+}
+
+var clusterNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Separates the name of the cluster from the ID of the object in the ID of
+// resources with a `cluster` attribute.
+const clusterIDSeparator = "/"
+
+// clustersSchema returns the schema of the `clusters` blocks, made of the
+// connection attributes of provider.
+func clustersSchema(provider map[string]*schema.Schema) *schema.Schema {
+	attributes := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(clusterNameRegexp, "must only contain letters, digits, hyphens and underscores"),
+			Description:  "Name of the cluster, chosen by resources with their `cluster` attribute.",
+		},
+	}
+	for _, k := range clusterConnectionAttributes {
+		s := *provider[k]
+		// Environment variables and conflicts only apply to the provider
+		// attributes.
+		s.DefaultFunc = nil
+		s.ConflictsWith = nil
+		attributes[k] = &s
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Additional clusters resources can target with their `cluster` attribute, each with its own connection settings. Connection settings not set for a cluster take their default value rather than the one of the provider, except `headers`. Other provider settings apply to every cluster.",
+		Elem:        &schema.Resource{Schema: attributes},
+	}
+}
+
+// clusterData is the configuration of a cluster, falling back to the one of
+// the provider for the attributes clusters can't set.
+type clusterData struct {
+	block    map[string]interface{}
+	provider *schema.ResourceData
+}
+
+func (c clusterData) Get(key string) interface{} {
+	if v, ok := c.block[key]; ok {
+		return v
+	}
+	return c.provider.Get(key)
+}
+
+func expandClusters(ctx context.Context, d *schema.ResourceData, headers map[string]string) (map[string]*ProviderConf, diag.Diagnostics) {
+	raw := d.Get("clusters").([]interface{})
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var diags diag.Diagnostics
+	clusters := make(map[string]*ProviderConf, len(raw))
+	for _, r := range raw {
+		m := r.(map[string]interface{})
+		name := m["name"].(string)
+		if _, ok := clusters[name]; ok {
+			return nil, diag.Errorf("cluster %q is configured more than once", name)
+		}
+
+		urls := expandStringList(m["urls"].([]interface{}))
+		if u := m["url"].(string); len(urls) == 0 && u != "" {
+			urls = []string{u}
+		}
+		if len(urls) == 0 {
+			return nil, diag.Errorf("one of `url` or `urls` must be set for cluster %q", name)
+		}
+
+		clusterHeaders := headers
+		if raw := m["headers"].(map[string]interface{}); len(raw) > 0 {
+			clusterHeaders = make(map[string]string, len(raw))
+			for k, v := range raw {
+				clusterHeaders[k] = v.(string)
+			}
+		}
+
+		conf, confDiags := newProviderConf(ctx, clusterData{block: m, provider: d}, urls, clusterHeaders)
+		for i := range confDiags {
+			confDiags[i].Summary = fmt.Sprintf("cluster %q: %s", name, confDiags[i].Summary)
+		}
+		diags = append(diags, confDiags...)
+		if confDiags.HasError() {
+			return nil, diags
+		}
+		clusters[name] = conf
+	}
+
+	return clusters, diags
+}
+
+// clusterConf returns the configuration of the cluster name, or of the
+// default cluster if name is empty.
+func (conf *ProviderConf) clusterConf(name string) (*ProviderConf, error) {
+	if name == "" {
+		if conf.rawUrl == "" {
+			return nil, fmt.Errorf("the provider has no default cluster, `cluster` must be set to one of %s", strings.Join(conf.clusterNames(), ", "))
+		}
+		return conf, nil
+	}
+
+	c, ok := conf.clusters[name]
+	if !ok {
+		if len(conf.clusters) == 0 {
+			return nil, fmt.Errorf("cluster %q is not configured, the provider has no `clusters`", name)
+		}
+		return nil, fmt.Errorf("cluster %q is not configured, expected one of %s", name, strings.Join(conf.clusterNames(), ", "))
+	}
+	return c, nil
+}
+
+func (conf *ProviderConf) clusterNames() []string {
+	names := make([]string, 0, len(conf.clusters))
+	for name := range conf.clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clusterResourceID returns the ID of the resource with the ID id on the
+// cluster name.
+func clusterResourceID(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return name + clusterIDSeparator + id
+}
+
+// parseClusterImportID splits an imported ID into the name of the cluster
+// and the ID of the object, if it starts with the name of a configured
+// cluster.
+func (conf *ProviderConf) parseClusterImportID(id string) (name string, objectID string) {
+	if name, objectID, ok := strings.Cut(id, clusterIDSeparator); ok {
+		if _, configured := conf.clusters[name]; configured {
+			return name, objectID
+		}
+	}
+	return "", id
+}
+
+// withCluster adds the `cluster` attribute to r and makes its functions use
+// the configuration of the chosen cluster. The ID of resources on a named
+// cluster is prefixed with the name of the cluster, which the functions of r
+// don't see.
+func withCluster(r *schema.Resource, dataSource bool) *schema.Resource {
+	r.Schema["cluster"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    !dataSource,
+		Description: "Name of the provider `clusters` entry to target. Defaults to the cluster of the provider.",
+	}
+
+	enter := func(d *schema.ResourceData, meta interface{}) (*ProviderConf, func(), error) {
+		name := d.Get("cluster").(string)
+		conf, err := meta.(*ProviderConf).clusterConf(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if name == "" || dataSource {
+			return conf, func() {}, nil
+		}
+
+		d.SetId(strings.TrimPrefix(d.Id(), name+clusterIDSeparator))
+		return conf, func() {
+			if d.Id() != "" {
+				d.SetId(clusterResourceID(name, d.Id()))
+			}
+		}, nil
+	}
+
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			conf, leave, err := enter(d, meta)
+			if err != nil {
+				return err
+			}
+			defer leave()
+			return f(d, conf)
+		}
+	}
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			conf, leave, err := enter(d, meta)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			defer leave()
+			return f(ctx, d, conf)
+		}
+	}
+
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+	r.CreateContext = wrapContext(r.CreateContext)
+	r.ReadContext = wrapContext(r.ReadContext)
+	r.UpdateContext = wrapContext(r.UpdateContext)
+	r.DeleteContext = wrapContext(r.DeleteContext)
+
+	customizeDiff := r.CustomizeDiff
+	if !dataSource {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if provider, ok := meta.(*ProviderConf); ok {
+				conf, err := provider.clusterConf(d.Get("cluster").(string))
+				if err != nil {
+					return err
+				}
+				meta = conf
+			}
+			if customizeDiff == nil {
+				return nil
+			}
+			return customizeDiff(ctx, d, meta)
+		}
+	}
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		state := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			provider := meta.(*ProviderConf)
+			name, id := provider.parseClusterImportID(d.Id())
+			conf, err := provider.clusterConf(name)
+			if err != nil {
+				return nil, err
+			}
+
+			d.SetId(id)
+			imported, err := state(ctx, d, conf)
+			if name == "" {
+				return imported, err
+			}
+			for _, rd := range imported {
+				if setErr := rd.Set("cluster", name); setErr != nil {
+					return nil, setErr
+				}
+				rd.SetId(clusterResourceID(name, rd.Id()))
+			}
+			return imported, err
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProviderClusters(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":                "http://default.example:9200",
+		"opensearch_version": "2.11.0",
+		"username":           "admin",
+		"password":           "default-password",
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":               "eu-1",
+				"urls":               []interface{}{"http://eu-1a.example:9200", "http://eu-1b.example:9200"},
+				"opensearch_version": "2.13.0",
+				"username":           "terraform",
+				"password":           "eu-password",
+			},
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	conf := provider.Meta().(*ProviderConf)
+	if conf.rawUrl != "http://default.example:9200" || conf.username != "admin" {
+		t.Errorf("unexpected default cluster configuration: %s as %s", conf.rawUrl, conf.username)
+	}
+
+	eu, err := conf.clusterConf("eu-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eu == conf || eu.rawUrl != "http://eu-1a.example:9200" || len(eu.urls) != 2 {
+		t.Errorf("unexpected eu-1 URLs: %v", eu.urls)
+	}
+	if eu.username != "terraform" || eu.password != "eu-password" || eu.osVersion != "2.13.0" {
+		t.Errorf("expected the connection settings of eu-1, got %s, %s", eu.username, eu.osVersion)
+	}
+	if eu.pingTimeoutSeconds != conf.pingTimeoutSeconds {
+		t.Errorf("expected the provider settings to apply to eu-1")
+	}
+
+	if _, err := conf.clusterConf("us-1"); err == nil || !strings.Contains(err.Error(), "eu-1") {
+		t.Errorf("expected an error listing the configured clusters, got %v", err)
+	}
+}
+
+func TestProviderClustersCredentials(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":                         "http://default.example:9200",
+		"opensearch_version":          "2.11.0",
+		"credential_process":          []interface{}{"/usr/local/bin/opensearch-credentials"},
+		"aws_web_identity_role_arn":   "arn:aws:iam::012345678901:role/default",
+		"aws_web_identity_token_file": "/var/run/secrets/token",
+		"oidc": []interface{}{
+			map[string]interface{}{
+				"token_endpoint": "https://idp.example/token",
+				"client_id":      "terraform",
+				"client_secret":  "default-secret",
+			},
+		},
+		"assume_role": []interface{}{
+			map[string]interface{}{"arn": "arn:aws:iam::012345678901:role/landing"},
+		},
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":               "eu-1",
+				"url":                "http://eu-1.example:9200",
+				"opensearch_version": "2.13.0",
+				"username":           "terraform",
+				"password":           "eu-password",
+			},
+			map[string]interface{}{
+				"name":               "us-1",
+				"url":                "http://us-1.example:9200",
+				"opensearch_version": "2.13.0",
+				"token":              "us-token",
+				"token_name":         "Bearer",
+			},
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	conf := provider.Meta().(*ProviderConf)
+	if conf.oidc == nil || len(conf.credentialProcess) != 1 || len(conf.awsAssumeRoleChain) != 1 {
+		t.Errorf("expected the provider credentials to apply to the default cluster")
+	}
+
+	eu, err := conf.clusterConf("eu-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eu.username != "terraform" || eu.password != "eu-password" || eu.token != "" {
+		t.Errorf("expected eu-1 to use basic auth as terraform, got %s", eu.username)
+	}
+
+	us, err := conf.clusterConf("us-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if us.token != "us-token" || us.tokenName != "Bearer" || us.username != "" || us.password != "" {
+		t.Errorf("expected us-1 to use its bearer token, got %s %s", us.tokenName, us.token)
+	}
+
+	for name, c := range map[string]*ProviderConf{"eu-1": eu, "us-1": us} {
+		if c.oidc != nil || len(c.credentialProcess) != 0 || len(c.awsAssumeRoleChain) != 0 {
+			t.Errorf("expected the provider credentials not to apply to %s", name)
+		}
+		if c.awsWebIdentityRoleArn != "" || c.awsWebIdentityTokenFile != "" {
+			t.Errorf("expected the provider web identity not to apply to %s, got %s", name, c.awsWebIdentityRoleArn)
+		}
+	}
+}
+
+func TestProviderClustersWithoutDefault(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"clusters": []interface{}{
+			map[string]interface{}{
+				"name":               "eu-1",
+				"url":                "http://eu-1.example:9200",
+				"opensearch_version": "2.13.0",
+			},
+		},
+	}))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	conf := provider.Meta().(*ProviderConf)
+	if _, err := conf.clusterConf(""); err == nil {
+		t.Errorf("expected an error without a default cluster")
+	}
+	if _, err := conf.clusterConf("eu-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWithCluster(t *testing.T) {
+	def := &ProviderConf{rawUrl: "http://default.example:9200"}
+	eu := &ProviderConf{rawUrl: "http://eu-1.example:9200"}
+	def.clusters = map[string]*ProviderConf{"eu-1": eu}
+
+	var gotConf *ProviderConf
+	var gotID string
+	r := withCluster(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true, ForceNew: true},
+		},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			gotConf = meta.(*ProviderConf)
+			d.SetId(d.Get("name").(string))
+			return nil
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			gotConf, gotID = meta.(*ProviderConf), d.Id()
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}, false)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "my-role", "cluster": "eu-1"})
+	if diags := r.CreateContext(context.Background(), d, def); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if gotConf != eu || d.Id() != "eu-1/my-role" {
+		t.Errorf("expected my-role to be created on eu-1 with the ID eu-1/my-role, got %s", d.Id())
+	}
+
+	if err := r.Read(d, def); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotConf != eu || gotID != "my-role" || d.Id() != "eu-1/my-role" {
+		t.Errorf("expected the read to see the ID my-role on eu-1, got %s", gotID)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "my-role"})
+	d.SetId("my-role")
+	if err := r.Read(d, def); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotConf != def || d.Id() != "my-role" {
+		t.Errorf("expected resources without cluster to use the default cluster, got %s", d.Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("eu-1/my-role")
+	imported, err := r.Importer.StateContext(context.Background(), d, def)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(imported) != 1 || imported[0].Id() != "eu-1/my-role" || imported[0].Get("cluster") != "eu-1" {
+		t.Errorf("expected the import to set cluster eu-1, got %s", imported[0].Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("other/my-role")
+	imported, err = r.Importer.StateContext(context.Background(), d, def)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if imported[0].Id() != "other/my-role" || imported[0].Get("cluster") != "" {
+		t.Errorf("expected an ID not starting with a cluster name to be imported on the default cluster, got %s", imported[0].Id())
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "my-role", "cluster": "us-1"})
+	if diags := r.CreateContext(context.Background(), d, def); !diags.HasError() {
+		t.Errorf("expected an error for an unknown cluster")
+	}
+}
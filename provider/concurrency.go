@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Updates are only applied to the version of an object Terraform last read,
+// so that concurrent runs don't silently overwrite each other. The APIs of
+// policies, monitors and detectors reject an update with a version conflict
+// if the sequence number and primary term of the object changed since. Other
+// APIs, e.g. those of the security plugin, have no such parameters: objects
+// are read again before an update and compared with their `etag`, a hash of
+// the object as last read.
+
+// versionParams returns the query parameters of an update conditional on the
+// `seq_no` and `primary_term` of d, if known.
+func versionParams(d *schema.ResourceData) url.Values {
+	params := url.Values{}
+	seq := d.Get("seq_no").(int)
+	primTerm := d.Get("primary_term").(int)
+	if seq >= 0 && primTerm > 0 {
+		params.Set("if_seq_no", strconv.Itoa(seq))
+		params.Set("if_primary_term", strconv.Itoa(primTerm))
+	}
+	return params
+}
+
+// conflictRetryStatusCodes returns the status codes to retry a write with
+// params on. A 409 is retried unless the write is conditional on the version
+// of the object, as it then means the object was modified since the last
+// refresh.
+func conflictRetryStatusCodes(params url.Values) []int {
+	if params.Get("if_seq_no") != "" {
+		return nil
+	}
+	return []int{http.StatusConflict}
+}
+
+// objectETag returns the etag of an object as read from the cluster.
+func objectETag(object interface{}) (string, error) {
+	j, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return hashSum(string(j)), nil
+}
+
+// checkETag returns an error if current, the object of d read again before
+// an update with the error readErr, was deleted or differs from the object of
+// the last refresh. Resources in a state predating etags are not checked.
+func checkETag(d *schema.ResourceData, kind string, current interface{}, readErr error) error {
+	if readErr != nil {
+		if isNotFound(readErr) {
+			return modifiedOutOfBandError(kind, d.Id(), readErr)
+		}
+		return readErr
+	}
+
+	stored := d.Get("etag").(string)
+	if stored == "" {
+		return nil
+	}
+
+	etag, err := objectETag(current)
+	if err != nil {
+		return err
+	}
+	if etag != stored {
+		return modifiedOutOfBandError(kind, d.Id(), ErrVersionConflict)
+	}
+	return nil
+}
+
+// versionConflictError returns the error of an update of an object modified
+// since the last refresh, or err if it is of another kind.
+func versionConflictError(kind, id string, err error) error {
+	if errors.Is(translateError(err), ErrVersionConflict) {
+		return modifiedOutOfBandError(kind, id, err)
+	}
+	return err
+}
+
+func modifiedOutOfBandError(kind, id string, err error) error {
+	return fmt.Errorf("%s %q was modified out-of-band since the last refresh, refresh the state and review the plan before applying again: %w", kind, id, err)
+}
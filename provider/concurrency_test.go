@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestVersionParams(t *testing.T) {
+	r := resourceOpenSearchMonitor()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"body": "{}"})
+	if params := versionParams(d); len(params) != 0 {
+		t.Errorf("expected no parameters without a known version, got %v", params)
+	}
+
+	_ = d.Set("seq_no", 0)
+	_ = d.Set("primary_term", 3)
+	if params := versionParams(d); params.Get("if_seq_no") != "0" || params.Get("if_primary_term") != "3" {
+		t.Errorf("unexpected parameters %v", params)
+	}
+}
+
+func TestRoleUpdateChecksETag(t *testing.T) {
+	role := `{"reader":{"cluster_permissions":["cluster_composite_ops_ro"],"index_permissions":[],"tenant_permissions":[],"description":"Read-only access"}}`
+	var puts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/":
+			_, _ = w.Write([]byte(`{"version":{"number":"2.13.0"}}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(role))
+		case r.Method == http.MethodPut:
+			puts.Add(1)
+			_, _ = w.Write([]byte(`{"status":"OK","message":"'reader' updated."}`))
+		}
+	}))
+	defer server.Close()
+
+	parsedUrl, _ := url.Parse(server.URL)
+	conf := &ProviderConf{rawUrl: server.URL, parsedUrl: parsedUrl, pingTimeoutSeconds: 5}
+
+	r := resourceOpenSearchRole()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"role_name": "reader"})
+	d.SetId("reader")
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Get("etag").(string) == "" {
+		t.Fatalf("expected the read to set the etag")
+	}
+
+	if err := r.Update(d, conf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puts.Load() != 1 {
+		t.Errorf("expected an unchanged role to be updated, got %d updates", puts.Load())
+	}
+
+	role = `{"reader":{"cluster_permissions":["cluster_all"],"index_permissions":[],"tenant_permissions":[],"description":"Read-only access"}}`
+	err := r.Update(d, conf)
+	if !errors.Is(err, ErrVersionConflict) || !strings.Contains(err.Error(), "modified out-of-band since the last refresh") {
+		t.Errorf("expected a conflict for a role modified since the refresh, got %v", err)
+	}
+	if puts.Load() != 1 {
+		t.Errorf("expected the modified role not to be overwritten")
+	}
+}
+
+func TestMonitorUpdateVersionConflict(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"2.13.0"}}`))
+			return
+		}
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"type":"version_conflict_engine_exception","reason":"[mon-1]: version conflict, required seqNo [4], primary term [1]. current document has seqNo [5] and primary term [1]"},"status":409}`))
+	}))
+	defer server.Close()
+
+	parsedUrl, _ := url.Parse(server.URL)
+	conf := &ProviderConf{rawUrl: server.URL, parsedUrl: parsedUrl, pingTimeoutSeconds: 5}
+
+	r := resourceOpenSearchMonitor()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"body": `{"name":"errors"}`})
+	d.SetId("mon-1")
+	_ = d.Set("seq_no", 4)
+	_ = d.Set("primary_term", 1)
+
+	err := r.Update(d, conf)
+	if query.Get("if_seq_no") != "4" || query.Get("if_primary_term") != "1" {
+		t.Errorf("expected the update to be conditional on the version, got %v", query)
+	}
+	if err == nil || !strings.Contains(err.Error(), `monitor "mon-1" was modified out-of-band`) {
+		t.Errorf("expected an out-of-band modification error, got %v", err)
+	}
+}
+
+func TestPolicyUpdateVersionConflictIsNotRetried(t *testing.T) {
+	cases := []struct {
+		resource string
+		path     string
+		config   func(description string) map[string]interface{}
+		setup    [][2]string
+	}{
+		{
+			resource: "opensearch_ism_policy",
+			path:     "/_plugins/_ism/policies/rollover",
+			config: func(description string) map[string]interface{} {
+				return map[string]interface{}{"policy_id": "rollover", "body": `{"policy":{"description":"` + description + `","default_state":"hot","states":[{"name":"hot","actions":[],"transitions":[]}]}}`}
+			},
+		},
+		{
+			resource: "opensearch_sm_policy",
+			path:     "/_plugins/_sm/policies/daily",
+			setup:    [][2]string{{"/_snapshot/backups", `{"type":"fs","settings":{"location":"/tmp/backups"}}`}},
+			config: func(description string) map[string]interface{} {
+				return map[string]interface{}{"policy_name": "daily", "body": `{"description":"` + description + `","creation":{"schedule":{"cron":{"expression":"0 0 * * *","timezone":"UTC"}}},"snapshot_config":{"repository":"backups"}}`}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.resource, func(t *testing.T) {
+			server := fakeopensearch.New(t)
+			for _, object := range tc.setup {
+				testUnitRequest(t, server, http.MethodPut, object[0], object[1])
+			}
+			p := testUnitProvider(t, server, nil)
+
+			created, err := testUnitApply(t, p, tc.resource, nil, tc.config("created"))
+			if err != nil {
+				t.Fatalf("unexpected error creating: %v", err)
+			}
+			// Another run updates the policy, the state of this one is stale.
+			if _, err := testUnitApply(t, p, tc.resource, created, tc.config("updated elsewhere")); err != nil {
+				t.Fatalf("unexpected error updating: %v", err)
+			}
+
+			puts := server.Requests(http.MethodPut, tc.path)
+			_, err = testUnitApply(t, p, tc.resource, created, tc.config("updated"))
+			if err == nil || !strings.Contains(err.Error(), "was modified out-of-band") {
+				t.Errorf("expected an out-of-band modification error, got %v", err)
+			}
+			if got := server.Requests(http.MethodPut, tc.path) - puts; got != 1 {
+				t.Errorf("expected the conflicting update to be sent once, got %d requests", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+var openDistroActionGroupSchema = map[string]*schema.Schema{
+	"action_group_name": {
+		Description: "The name of the action group.",
+		Type:        schema.TypeString,
+		Required:    true,
+	},
+	"allowed_actions": {
+		Description: "The permissions and action groups the action group is made of.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"type": {
+		Description: "The kind of permissions of the action group, `cluster`, `index` or `kibana`.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+	"description": {
+		Description: "Description of the action group.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
+}
+
+func dataSourceOpensearchActionGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_action_group` can be used to look up an action group of the security plugin, e.g. to check the permissions of a built-in one such as `read`.",
+		ReadContext: dataSourceOpensearchActionGroupRead,
+		Schema:      dataSourceSecurityObjectSchema(openDistroActionGroupSchema, "action_group_name"),
+	}
+}
+
+func dataSourceOpensearchActionGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_action_groups` can be used to list the action groups of the security plugin whose names match a regular expression.",
+		ReadContext: dataSourceOpensearchActionGroupsRead,
+		Schema:      dataSourceSecurityObjectsSchema(openDistroActionGroupSchema, "action groups", "action_groups"),
+	}
+}
+
+func dataSourceOpensearchActionGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("action_group_name").(string)
+	actionGroup, err := resourceOpensearchGetOpenDistroActionGroup(ctx, name, m)
+	if err != nil {
+		return securityObjectReadError("action group", name, err)
+	}
+
+	return setSecurityObject(d, name, flattenActionGroup(name, actionGroup))
+}
+
+func dataSourceOpensearchActionGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	names, objects, err := listSecurityObjects(ctx, d, m, "actiongroups")
+	if err != nil {
+		return diag.Errorf("error listing the action groups: %s", err)
+	}
+
+	actionGroups := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		var actionGroup ActionGroupBody
+		if err := json.Unmarshal(objects[name], &actionGroup); err != nil {
+			return diag.Errorf("error unmarshalling action group %s: %s", name, err)
+		}
+		actionGroups = append(actionGroups, flattenActionGroup(name, actionGroup))
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("action_groups", actionGroups)
+
+	return diag.FromErr(ds.err)
+}
+
+func resourceOpensearchGetOpenDistroActionGroup(ctx context.Context, actionGroupID string, m interface{}) (ActionGroupBody, error) {
+	var actionGroup ActionGroupBody
+
+	path, err := uritemplates.Expand("/_plugins/_security/api/actiongroups/{name}", map[string]string{
+		"name": actionGroupID,
+	})
+	if err != nil {
+		return actionGroup, fmt.Errorf("error building URL path for action group: %+v", err)
+	}
+
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return actionGroup, err
+	}
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return actionGroup, err
+	}
+
+	var actionGroupDefinition map[string]ActionGroupBody
+	if err := json.Unmarshal(res.Body, &actionGroupDefinition); err != nil {
+		return actionGroup, fmt.Errorf("error unmarshalling action group body: %+v: %+v", err, res.Body)
+	}
+
+	return actionGroupDefinition[actionGroupID], nil
+}
+
+func flattenActionGroup(name string, actionGroup ActionGroupBody) map[string]interface{} {
+	return actionGroup.SecurityObjectFlags.flatten(map[string]interface{}{
+		"action_group_name": name,
+		"allowed_actions":   flattenStringSet(actionGroup.AllowedActions),
+		"type":              actionGroup.Type,
+		"description":       actionGroup.Description,
+	})
+}
+
+type ActionGroupBody struct {
+	AllowedActions []string `json:"allowed_actions"`
+	Type           string   `json:"type,omitempty"`
+	Description    string   `json:"description"`
+	SecurityObjectFlags
+}
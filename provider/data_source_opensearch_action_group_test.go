@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceActionGroup_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceActionGroup,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "id", "read"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "type", "index"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "static", "true"),
+					resource.TestCheckResourceAttrSet("data.opensearch_action_group.read", "allowed_actions.#"),
+					resource.TestCheckResourceAttr("data.opensearch_action_groups.read", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.opensearch_action_groups.read", "action_groups.0.action_group_name", "read"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceActionGroup(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceActionGroup,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "id", "read"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "type", "index"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "reserved", "true"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "static", "true"),
+					resource.TestCheckResourceAttr("data.opensearch_action_group.read", "allowed_actions.#", "3"),
+					resource.TestCheckTypeSetElemAttr("data.opensearch_action_group.read", "allowed_actions.*", "indices:data/read*"),
+					resource.TestCheckResourceAttr("data.opensearch_action_groups.read", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.opensearch_action_groups.read", "action_groups.0.action_group_name", "read"),
+				),
+			},
+		},
+	})
+}
+
+var testAccOpensearchDataSourceActionGroup = `
+data "opensearch_action_group" "read" {
+  action_group_name = "read"
+}
+
+data "opensearch_action_groups" "read" {
+  name_regex = "^read$"
+}
+`
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func dataSourceOpensearchClusterHealth() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_cluster_health` can be used to retrieve the health of the cluster, or of some indices, optionally waiting for it to reach a status so that the resources depending on it are only created once it does.",
+		ReadContext: dataSourceOpensearchClusterHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comma separated list of indices, aliases or wildcards to limit the health to. Defaults to all the indices of the cluster.",
+			},
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"green", "yellow", "red"}, false),
+				Description:  "Wait until the status is this one or better, i.e. `green`, `yellow` or `red`. Reading fails if it isn't reached within `timeout`.",
+			},
+			"wait_for_active_shards": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Wait until this number of shards is active, or `all` of them. Reading fails if they aren't within `timeout`.",
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(timeValueRegexp, "must be a number followed by one of the units d, h, m, s, ms, micros or nanos, e.g. 30s"),
+				Description:  "How long to wait for the conditions, e.g. `5m`, in the time units of OpenSearch. Defaults to 30 seconds. Must be shorter than the `request_timeout` of the provider, if any.",
+			},
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the cluster",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the status of the health, `green`, `yellow` or `red`",
+			},
+			"number_of_nodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of nodes of the cluster",
+			},
+			"number_of_data_nodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of data nodes of the cluster",
+			},
+			"active_primary_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of active primary shards",
+			},
+			"active_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of active primary and replica shards",
+			},
+			"relocating_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of shards being relocated",
+			},
+			"initializing_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of shards being initialized",
+			},
+			"unassigned_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of shards not assigned to a node",
+			},
+			"delayed_unassigned_shards": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of unassigned shards whose allocation is delayed",
+			},
+			"number_of_pending_tasks": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "the number of cluster level changes not executed yet",
+			},
+			"active_shards_percent": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "the ratio of active shards, as a percentage",
+			},
+		},
+	}
+}
+
+// How long the cluster health waits for its conditions without a timeout.
+const defaultClusterHealthTimeout = 30 * time.Second
+
+type clusterHealthResponse struct {
+	ClusterName             string  `json:"cluster_name"`
+	Status                  string  `json:"status"`
+	TimedOut                bool    `json:"timed_out"`
+	NumberOfNodes           int     `json:"number_of_nodes"`
+	NumberOfDataNodes       int     `json:"number_of_data_nodes"`
+	ActivePrimaryShards     int     `json:"active_primary_shards"`
+	ActiveShards            int     `json:"active_shards"`
+	RelocatingShards        int     `json:"relocating_shards"`
+	InitializingShards      int     `json:"initializing_shards"`
+	UnassignedShards        int     `json:"unassigned_shards"`
+	DelayedUnassignedShards int     `json:"delayed_unassigned_shards"`
+	NumberOfPendingTasks    int     `json:"number_of_pending_tasks"`
+	ActiveShardsPercent     float64 `json:"active_shards_percent_as_number"`
+}
+
+func dataSourceOpensearchClusterHealthRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conf := m.(*ProviderConf)
+	if diags := checkClusterHealthTimeout(d, conf); diags.HasError() {
+		return diags
+	}
+
+	osClient, err := getClient(conf)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := "/_cluster/health"
+	if index := d.Get("index").(string); index != "" {
+		path += "/" + url.PathEscape(index)
+	}
+	params := url.Values{}
+	for _, key := range []string{"wait_for_status", "wait_for_active_shards", "timeout"} {
+		if v := d.Get(key).(string); v != "" {
+			params.Set(key, v)
+		}
+	}
+
+	// The health is answered with a 408 if the conditions aren't met in time,
+	// which is reported with the health at that time below.
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method:       "GET",
+		Path:         path,
+		Params:       params,
+		IgnoreErrors: []int{http.StatusRequestTimeout},
+	})
+	if err != nil {
+		return diag.Errorf("error getting the cluster health: %s", err)
+	}
+	var health clusterHealthResponse
+	if err := json.Unmarshal(res.Body, &health); err != nil {
+		return diag.Errorf("error unmarshalling the cluster health: %s", err)
+	}
+	if health.TimedOut {
+		return diag.Errorf("timed out waiting for the cluster health: status is %s with %d active and %d unassigned shards", health.Status, health.ActiveShards, health.UnassignedShards)
+	}
+
+	d.SetId(health.ClusterName)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("cluster_name", health.ClusterName)
+	ds.set("status", health.Status)
+	ds.set("number_of_nodes", health.NumberOfNodes)
+	ds.set("number_of_data_nodes", health.NumberOfDataNodes)
+	ds.set("active_primary_shards", health.ActivePrimaryShards)
+	ds.set("active_shards", health.ActiveShards)
+	ds.set("relocating_shards", health.RelocatingShards)
+	ds.set("initializing_shards", health.InitializingShards)
+	ds.set("unassigned_shards", health.UnassignedShards)
+	ds.set("delayed_unassigned_shards", health.DelayedUnassignedShards)
+	ds.set("number_of_pending_tasks", health.NumberOfPendingTasks)
+	ds.set("active_shards_percent", health.ActiveShardsPercent)
+
+	return diag.FromErr(ds.err)
+}
+
+// checkClusterHealthTimeout checks that the cluster answers before the
+// request_timeout of the provider when it waits for the conditions of d, as
+// the request would fail without the health otherwise.
+func checkClusterHealthTimeout(d *schema.ResourceData, conf *ProviderConf) diag.Diagnostics {
+	if conf.http == nil || conf.http.requestTimeout <= 0 {
+		return nil
+	}
+	if d.Get("wait_for_status").(string) == "" && d.Get("wait_for_active_shards").(string) == "" {
+		return nil
+	}
+
+	timeout := defaultClusterHealthTimeout
+	if v := d.Get("timeout").(string); v != "" {
+		var err error
+		if timeout, err = parseTimeValue(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if timeout >= conf.http.requestTimeout {
+		return diag.Errorf("timeout (%s) must be shorter than the request_timeout of the provider (%s)", timeout, conf.http.requestTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceClusterHealth_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceClusterHealth,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_health.test", "cluster_name"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_health.test", "status"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_health.test", "number_of_nodes"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceClusterHealth(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceClusterHealthIndex,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opensearch_cluster_health.test", "cluster_name", "fake-cluster"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_health.test", "status", "green"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_health.test", "active_primary_shards", "2"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_health.test", "unassigned_shards", "0"),
+				),
+			},
+			{
+				// A single node cluster can't assign replicas.
+				Config:      testAccOpensearchDataSourceClusterHealthTimeout,
+				ExpectError: regexp.MustCompile("timed out waiting for the cluster health: status is yellow"),
+			},
+		},
+	})
+}
+
+func TestOpensearchDataSourceClusterHealthRead(t *testing.T) {
+	server := fakeopensearch.New(t)
+	testUnitRequest(t, server, http.MethodPut, "/terraform-test-green", `{"settings":{"index":{"number_of_shards":2,"number_of_replicas":0}}}`)
+	testUnitRequest(t, server, http.MethodPut, "/terraform-test-yellow", `{"settings":{"index":{"number_of_shards":1,"number_of_replicas":1}}}`)
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		want   map[string]string
+		err    string
+	}{
+		{
+			name:   "cluster",
+			config: map[string]interface{}{},
+			want:   map[string]string{"id": "fake-cluster", "status": "yellow", "active_primary_shards": "3", "unassigned_shards": "1"},
+		},
+		{
+			name:   "status reached",
+			config: map[string]interface{}{"index": "terraform-test-green", "wait_for_status": "green"},
+			want:   map[string]string{"status": "green", "active_shards": "2", "unassigned_shards": "0"},
+		},
+		{
+			name:   "status timed out",
+			config: map[string]interface{}{"index": "terraform-test-yellow", "wait_for_status": "green", "timeout": "1s"},
+			err:    "timed out waiting for the cluster health: status is yellow with 1 active and 1 unassigned shards",
+		},
+		{
+			name:   "active shards timed out",
+			config: map[string]interface{}{"index": "terraform-test-*", "wait_for_active_shards": "all"},
+			err:    "timed out waiting for the cluster health: status is yellow with 3 active and 1 unassigned shards",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes, diags := testUnitReadDataSource(t, server, nil, "opensearch_cluster_health", tc.config)
+			if tc.err != "" {
+				if !diags.HasError() || diags[0].Summary != tc.err {
+					t.Errorf("expected the error %q, got %v", tc.err, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			for key, value := range tc.want {
+				if attributes[key] != value {
+					t.Errorf("%s: got %q, want %q", key, attributes[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestOpensearchDataSourceClusterHealthTimeout(t *testing.T) {
+	server := fakeopensearch.New(t)
+	providerConfig := map[string]interface{}{
+		"http": []interface{}{map[string]interface{}{"request_timeout": "1m"}},
+	}
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			name:   "time value",
+			config: map[string]interface{}{"wait_for_status": "green", "timeout": "30000ms"},
+		},
+		{
+			name:   "Go duration",
+			config: map[string]interface{}{"wait_for_status": "green", "timeout": "1m30s"},
+			err:    "must be a number followed by one of the units",
+		},
+		{
+			name:   "longer than request_timeout",
+			config: map[string]interface{}{"wait_for_status": "green", "timeout": "2m"},
+			err:    `timeout \(2m0s\) must be shorter than the request_timeout of the provider \(1m0s\)`,
+		},
+		{
+			name:   "not waiting",
+			config: map[string]interface{}{"timeout": "2m"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, diags := testUnitReadDataSource(t, server, providerConfig, "opensearch_cluster_health", tc.config)
+			if tc.err == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() || !regexp.MustCompile(tc.err).MatchString(diags[0].Summary) {
+				t.Errorf("expected an error matching %q, got %v", tc.err, diags)
+			}
+		})
+	}
+}
+
+var testAccOpensearchDataSourceClusterHealth = `
+data "opensearch_cluster_health" "test" {
+  wait_for_status = "yellow"
+  timeout         = "30s"
+}
+`
+
+var testAccOpensearchDataSourceClusterHealthIndex = `
+resource "opensearch_index" "test" {
+  name               = "terraform-test-health"
+  number_of_shards   = 2
+  number_of_replicas = 0
+}
+
+data "opensearch_cluster_health" "test" {
+  index           = opensearch_index.test.name
+  wait_for_status = "green"
+}
+`
+
+var testAccOpensearchDataSourceClusterHealthTimeout = `
+resource "opensearch_index" "test" {
+  name               = "terraform-test-health"
+  number_of_shards   = 2
+  number_of_replicas = 1
+}
+
+data "opensearch_cluster_health" "test" {
+  index           = opensearch_index.test.name
+  wait_for_status = "green"
+  timeout         = "1s"
+}
+`
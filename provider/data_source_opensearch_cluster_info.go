@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func dataSourceOpensearchClusterInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_cluster_info` can be used to retrieve the version of the cluster, its nodes and the plugins installed on them, e.g. to only create resources supported by the cluster.",
+		ReadContext: dataSourceOpensearchClusterInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the name of the cluster",
+			},
+			"cluster_uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the UUID of the cluster",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the version of the node that answered, e.g. `2.19.0`",
+			},
+			"distribution": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the distribution of the node that answered, `opensearch` unless the cluster runs Elasticsearch",
+			},
+			"lucene_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the version of Lucene of the node that answered",
+			},
+			"plugins": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "the plugins installed on any node of the cluster, e.g. `opensearch-ml`, empty if they can't be listed",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "the nodes of the cluster, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the ID of the node",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the name of the node",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the host name of the node",
+						},
+						"ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the IP address of the node",
+						},
+						"transport_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the address other nodes connect to",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the version of OpenSearch the node runs",
+						},
+						"roles": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the roles of the node, e.g. `cluster_manager` or `data`",
+						},
+						"attributes": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the custom attributes of the node",
+						},
+						"plugins": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the plugins installed on the node, empty if they can't be listed",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type clusterInfoResponse struct {
+	ClusterName string `json:"cluster_name"`
+	ClusterUUID string `json:"cluster_uuid"`
+	Version     struct {
+		Number        string `json:"number"`
+		Distribution  string `json:"distribution"`
+		LuceneVersion string `json:"lucene_version"`
+	} `json:"version"`
+}
+
+type clusterNodeInfo struct {
+	Name             string            `json:"name"`
+	Host             string            `json:"host"`
+	IP               string            `json:"ip"`
+	TransportAddress string            `json:"transport_address"`
+	Version          string            `json:"version"`
+	Roles            []string          `json:"roles"`
+	Attributes       map[string]string `json:"attributes"`
+}
+
+func dataSourceOpensearchClusterInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var info clusterInfoResponse
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/",
+	})
+	if err != nil {
+		return diag.Errorf("error getting the cluster info: %s", err)
+	}
+	if err := json.Unmarshal(res.Body, &info); err != nil {
+		return diag.Errorf("error unmarshalling the cluster info: %s", err)
+	}
+	// Elasticsearch doesn't report a distribution.
+	if info.Version.Distribution == "" {
+		info.Version.Distribution = "elasticsearch"
+	}
+
+	var nodesResponse struct {
+		Nodes map[string]clusterNodeInfo `json:"nodes"`
+	}
+	res, err = osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/_nodes",
+	})
+	if err != nil {
+		return diag.Errorf("error getting the nodes of the cluster: %s", err)
+	}
+	if err := json.Unmarshal(res.Body, &nodesResponse); err != nil {
+		return diag.Errorf("error unmarshalling the nodes of the cluster: %s", err)
+	}
+
+	var catPlugins []struct {
+		Name      string `json:"name"`
+		Component string `json:"component"`
+	}
+	res, err = osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/_cat/plugins",
+		Params: url.Values{"format": []string{"json"}},
+	})
+	var osErr *OpenSearchError
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(res.Body, &catPlugins); err != nil {
+			return diag.Errorf("error unmarshalling _cat/plugins: %s", err)
+		}
+	case errors.As(translateError(err), &osErr) && (osErr.StatusCode == http.StatusForbidden || osErr.StatusCode == http.StatusNotFound):
+		// e.g. without the permission to list them, or on a managed
+		// service not exposing them
+		log.Printf("[WARN] Unable to list the plugins of the cluster, leaving them empty: %s", err)
+	default:
+		return diag.Errorf("error listing the plugins of the cluster: %s", err)
+	}
+	plugins := []string{}
+	nodePlugins := map[string][]string{}
+	for _, p := range catPlugins {
+		if !containsString(plugins, p.Component) {
+			plugins = append(plugins, p.Component)
+		}
+		nodePlugins[p.Name] = append(nodePlugins[p.Name], p.Component)
+	}
+
+	ids := make([]string, 0, len(nodesResponse.Nodes))
+	for id := range nodesResponse.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return nodesResponse.Nodes[ids[i]].Name < nodesResponse.Nodes[ids[j]].Name
+	})
+	nodes := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		node := nodesResponse.Nodes[id]
+		nodes = append(nodes, map[string]interface{}{
+			"id":                id,
+			"name":              node.Name,
+			"host":              node.Host,
+			"ip":                node.IP,
+			"transport_address": node.TransportAddress,
+			"version":           node.Version,
+			"roles":             node.Roles,
+			"attributes":        node.Attributes,
+			"plugins":           nodePlugins[node.Name],
+		})
+	}
+
+	// The UUID is `_na_` until the cluster state is recovered.
+	if info.ClusterUUID != "" && info.ClusterUUID != "_na_" {
+		d.SetId(info.ClusterUUID)
+	} else {
+		d.SetId(info.ClusterName)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("cluster_name", info.ClusterName)
+	ds.set("cluster_uuid", info.ClusterUUID)
+	ds.set("version", info.Version.Number)
+	ds.set("distribution", info.Version.Distribution)
+	ds.set("lucene_version", info.Version.LuceneVersion)
+	ds.set("plugins", plugins)
+	ds.set("nodes", nodes)
+
+	return diag.FromErr(ds.err)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceClusterInfo_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceClusterInfo,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_info.test", "id"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_info.test", "cluster_name"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_info.test", "version"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "distribution", "opensearch"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_info.test", "nodes.0.name"),
+					resource.TestCheckResourceAttrSet("data.opensearch_cluster_info.test", "nodes.0.roles.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceClusterInfo(t *testing.T) {
+	server := fakeopensearch.New(t, fakeopensearch.WithVersion("2.11.0"), fakeopensearch.WithPlugins("opensearch-ml", "opensearch-security"))
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceClusterInfo,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "id", "fake-cluster-uuid"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "cluster_name", "fake-cluster"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "version", "2.11.0"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "distribution", "opensearch"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "plugins.#", "2"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "plugins.0", "opensearch-ml"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "nodes.0.name", "fake-node"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "nodes.0.roles.0", "cluster_manager"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "nodes.0.attributes.shard_indexing_pressure_enabled", "true"),
+					resource.TestCheckResourceAttr("data.opensearch_cluster_info.test", "nodes.0.plugins.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+var testAccOpensearchDataSourceClusterInfo = `
+data "opensearch_cluster_info" "test" {}
+`
+
+func TestOpensearchDataSourceClusterInfoWithoutPluginsPermission(t *testing.T) {
+	server := fakeopensearch.New(t, fakeopensearch.WithForbidden("/_cat/plugins"))
+
+	attributes, diags := testUnitReadDataSource(t, server, nil, "opensearch_cluster_info", map[string]interface{}{})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if attributes["version"] != fakeopensearch.DefaultVersion || attributes["nodes.#"] != "1" {
+		t.Errorf("expected the version and nodes to be read, got %v", attributes)
+	}
+	if attributes["plugins.#"] != "0" || attributes["nodes.0.plugins.#"] != "0" {
+		t.Errorf("expected the plugins to be left empty, got %s and %s", attributes["plugins.#"], attributes["nodes.0.plugins.#"])
+	}
+}
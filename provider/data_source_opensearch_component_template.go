@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchComponentTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_component_template` can be used to read a component template, e.g. to check the mappings it provides before adding it to the `composed_of` list of an index template.",
+		ReadContext: dataSourceOpensearchTemplateRead("component template", elastic7GetComponentTemplate),
+		Schema:      dataSourceOpensearchTemplateSchema("component template"),
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceComponentTemplate_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceComponentTemplate,
+				Check:  testCheckOpensearchDataSourceComponentTemplate,
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceComponentTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceComponentTemplate,
+				Check:  testCheckOpensearchDataSourceComponentTemplate,
+			},
+		},
+	})
+}
+
+var testCheckOpensearchDataSourceComponentTemplate = resource.ComposeAggregateTestCheckFunc(
+	resource.TestCheckResourceAttr("data.opensearch_component_template.test", "id", "terraform-test-ds-component-template"),
+	resource.TestMatchResourceAttr("data.opensearch_component_template.test", "body", regexp.MustCompile(`"message":\{"type":"text"\}`)),
+)
+
+var testAccOpensearchDataSourceComponentTemplate = `
+resource "opensearch_component_template" "test" {
+  name = "terraform-test-ds-component-template"
+  body = jsonencode({
+    template = {
+      mappings = {
+        properties = {
+          message = { type = "text" }
+        }
+      }
+    }
+  })
+}
+
+data "opensearch_component_template" "test" {
+  name = opensearch_component_template.test.name
+}
+`
@@ -0,0 +1,13 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchComposableIndexTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_composable_index_template` can be used to read a composable index template, e.g. to build on one managed by another team. Use `opensearch_simulate_index` to get the settings and mappings it results in once merged with its component templates.",
+		ReadContext: dataSourceOpensearchTemplateRead("composable index template", elastic7GetIndexTemplate),
+		Schema:      dataSourceOpensearchTemplateSchema("composable index template"),
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceComposableIndexTemplate_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceComposableIndexTemplate,
+				Check:  testCheckOpensearchDataSourceComposableIndexTemplate,
+			},
+		},
+	})
+}
+
+func TestOpensearchDataSourceComposableIndexTemplateNotFound(t *testing.T) {
+	server := fakeopensearch.New(t)
+
+	_, diags := testUnitReadDataSource(t, server, nil, "opensearch_composable_index_template", map[string]interface{}{"name": "missing"})
+	if !diags.HasError() || diags[0].Summary != `composable index template "missing" not found` {
+		t.Errorf("expected the composable index template not to be found, got %v", diags)
+	}
+}
+
+func TestUnitOpensearchDataSourceComposableIndexTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceComposableIndexTemplate,
+				Check:  testCheckOpensearchDataSourceComposableIndexTemplate,
+			},
+		},
+	})
+}
+
+var testCheckOpensearchDataSourceComposableIndexTemplate = resource.ComposeAggregateTestCheckFunc(
+	resource.TestCheckResourceAttr("data.opensearch_composable_index_template.test", "id", "terraform-test-ds-composable-index-template"),
+	resource.TestMatchResourceAttr("data.opensearch_composable_index_template.test", "body", regexp.MustCompile(`"index_patterns":\["terraform-test-ds-composable-index-template-\*"\]`)),
+	resource.TestMatchResourceAttr("data.opensearch_composable_index_template.test", "body", regexp.MustCompile(`"number_of_shards":"1"`)),
+)
+
+var testAccOpensearchDataSourceComposableIndexTemplate = `
+resource "opensearch_composable_index_template" "test" {
+  name = "terraform-test-ds-composable-index-template"
+  body = jsonencode({
+    index_patterns = ["terraform-test-ds-composable-index-template-*"]
+    template = {
+      settings = {
+        index = {
+          number_of_shards = "1"
+        }
+      }
+    }
+  })
+}
+
+data "opensearch_composable_index_template" "test" {
+  name = opensearch_composable_index_template.test.name
+}
+`
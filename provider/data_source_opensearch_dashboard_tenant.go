@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchDashboardTenant() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_dashboard_tenant` can be used to look up an OpenSearch Dashboards tenant, including the built-in `global_tenant`.",
+		ReadContext: dataSourceOpensearchDashboardTenantRead,
+		Schema:      dataSourceSecurityObjectSchema(openSearchDashboardTenantSchema, "tenant_name"),
+	}
+}
+
+func dataSourceOpensearchDashboardTenants() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_dashboard_tenants` can be used to list the OpenSearch Dashboards tenants whose names match a regular expression.",
+		ReadContext: dataSourceOpensearchDashboardTenantsRead,
+		Schema:      dataSourceSecurityObjectsSchema(openSearchDashboardTenantSchema, "tenants", "tenants"),
+	}
+}
+
+func dataSourceOpensearchDashboardTenantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("tenant_name").(string)
+	var tenant tenantEntry
+	if err := getSecurityObject(ctx, m, "tenants", name, &tenant); err != nil {
+		return securityObjectReadError("tenant", name, err)
+	}
+
+	object, err := flattenDashboardTenant(name, tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return setSecurityObject(d, name, object)
+}
+
+func dataSourceOpensearchDashboardTenantsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	names, objects, err := listSecurityObjects(ctx, d, m, "tenants")
+	if err != nil {
+		return diag.Errorf("error listing the tenants: %s", err)
+	}
+
+	tenants := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		var tenant tenantEntry
+		if err := json.Unmarshal(objects[name], &tenant); err != nil {
+			return diag.Errorf("error unmarshalling tenant %s: %s", name, err)
+		}
+		object, err := flattenDashboardTenant(name, tenant)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tenants = append(tenants, object)
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("tenants", tenants)
+
+	return diag.FromErr(ds.err)
+}
+
+// tenantEntry is a tenant as read by the data sources, with its flags.
+type tenantEntry struct {
+	TenantBody
+	SecurityObjectFlags
+}
+
+func flattenDashboardTenant(name string, tenant tenantEntry) (map[string]interface{}, error) {
+	index, err := resourceOpensearchOpenDistroDashboardComputeIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.SecurityObjectFlags.flatten(map[string]interface{}{
+		"tenant_name": name,
+		"description": tenant.Description,
+		"index":       index,
+	}), nil
+}
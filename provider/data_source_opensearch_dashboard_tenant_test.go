@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceDashboardTenant_basic(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
+	if diags.HasError() {
+		t.Skipf("err: %#v", diags)
+	}
+
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckOpensearchDashboardTenantDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceDashboardTenant(randomName),
+				Check:  testCheckOpensearchDataSourceDashboardTenant(randomName),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceDashboardTenant(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_dashboard_tenant", "/_plugins/_security/api/tenants/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceDashboardTenant(randomName),
+				Check:  testCheckOpensearchDataSourceDashboardTenant(randomName),
+			},
+		},
+	})
+}
+
+func testCheckOpensearchDataSourceDashboardTenant(name string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("data.opensearch_dashboard_tenant.test", "id", name),
+		resource.TestCheckResourceAttr("data.opensearch_dashboard_tenant.test", "description", "test"),
+		resource.TestCheckResourceAttrPair("data.opensearch_dashboard_tenant.test", "index", "opensearch_dashboard_tenant.test", "index"),
+		resource.TestCheckResourceAttr("data.opensearch_dashboard_tenant.global", "reserved", "true"),
+		resource.TestCheckResourceAttr("data.opensearch_dashboard_tenants.test", "names.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_dashboard_tenants.test", "tenants.0.tenant_name", name),
+	)
+}
+
+func testAccOpensearchDataSourceDashboardTenant(name string) string {
+	return fmt.Sprintf(`
+resource "opensearch_dashboard_tenant" "test" {
+  tenant_name = "%s"
+  description = "test"
+}
+
+data "opensearch_dashboard_tenant" "test" {
+  tenant_name = opensearch_dashboard_tenant.test.tenant_name
+}
+
+data "opensearch_dashboard_tenant" "global" {
+  tenant_name = "global_tenant"
+}
+
+data "opensearch_dashboard_tenants" "test" {
+  name_regex = "^${opensearch_dashboard_tenant.test.tenant_name}$"
+}
+`, name)
+}
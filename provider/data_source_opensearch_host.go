@@ -1,9 +1,11 @@
 package provider
 
 import (
-	"reflect"
+	"context"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
 )
 
 func dataSourceOpensearchHost() *schema.Resource {
@@ -20,30 +22,58 @@ func dataSourceOpensearchHost() *schema.Resource {
 			"url": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "the url of the active cluster",
+				Description: "the url of the active cluster node, i.e. the one that answered the last request",
+			},
+			"urls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "the urls of all the cluster nodes configured in the provider",
 			},
 		},
 	}
 }
 
 func dataSourceOpensearchHostRead(d *schema.ResourceData, m interface{}) error {
-
-	// The upstream client does not export the property for the urls
-	// it's using. Presumably the URLS would be available where the client is
-	// intantiated, but in terraform, that's not always practicable.
-	var err error
-	osClient, err := getClient(m.(*ProviderConf))
+	conf := m.(*ProviderConf)
+	osClient, err := getClient(conf)
 	if err != nil {
 		return err
 	}
 
-	var url string
-	urls := reflect.ValueOf(osClient).Elem().FieldByName("urls")
-	if urls.Len() > 0 {
-		url = urls.Index(0).String()
+	// Unless a request was already made, e.g. to determine the server
+	// version, make one so the client picks a node that is available.
+	if conf.activeUrl.Load() == nil {
+		_, err = osClient.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
+			Method: "HEAD",
+			Path:   "/",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	active := redactURLCredentials(conf.activeURL())
+	d.SetId(active)
+
+	var urls []string
+	for _, u := range conf.clusterURLs() {
+		urls = append(urls, redactURLCredentials(u))
 	}
-	d.SetId(url)
-	err = d.Set("url", url)
 
-	return err
+	ds := &resourceDataSetter{d: d}
+	ds.set("url", active)
+	ds.set("urls", urls)
+
+	return ds.err
+}
+
+// redactURLCredentials strips basic auth credentials embedded in a URL.
+func redactURLCredentials(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.User == nil {
+		return rawUrl
+	}
+	u.User = nil
+	return u.String()
 }
@@ -20,6 +20,7 @@ func TestAccOpensearchDataSourceHost_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.opensearch_host.test", "id"),
 					resource.TestCheckResourceAttrSet("data.opensearch_host.test", "url"),
+					resource.TestCheckResourceAttr("data.opensearch_host.test", "urls.#", "1"),
 				),
 			},
 		},
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func dataSourceOpensearchIndexTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_index_template` can be used to read an index template the way `opensearch_index_template` resources manage them, e.g. one managed by another team.",
+		ReadContext: dataSourceOpensearchTemplateRead("index template", elastic7IndexGetTemplate),
+		Schema:      dataSourceOpensearchTemplateSchema("index template"),
+	}
+}
+
+func dataSourceOpensearchTemplateSchema(kind string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: fmt.Sprintf("The name of the %s.", kind),
+		},
+		"body": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("the JSON body of the %s, which can be read with `jsondecode`", kind),
+		},
+	}
+}
+
+// dataSourceOpensearchTemplateRead returns the read function of the data
+// source of a kind of template, which gets it like its resource does.
+func dataSourceOpensearchTemplateRead(kind string, get func(*elastic7.Client, string) (string, error)) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		providerConf := m.(*ProviderConf)
+		osClient, err := getClient(providerConf)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		name := d.Get("name").(string)
+		body, err := get(osClient, name)
+		if err != nil {
+			if isNotFound(err) {
+				return diag.Errorf("%s %q not found", kind, name)
+			}
+			return diag.Errorf("error getting the %s %q: %s", kind, name, err)
+		}
+
+		if providerConf.isServerless() {
+			body, err = stripServerlessManagedTemplateSettings(body)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.SetId(name)
+
+		ds := &resourceDataSetter{d: d}
+		ds.set("body", body)
+
+		return diag.FromErr(ds.err)
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceIndexTemplate_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceIndexTemplate,
+				Check:  testCheckOpensearchDataSourceIndexTemplate,
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceIndexTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceIndexTemplate,
+				Check:  testCheckOpensearchDataSourceIndexTemplate,
+			},
+		},
+	})
+}
+
+var testCheckOpensearchDataSourceIndexTemplate = resource.ComposeAggregateTestCheckFunc(
+	resource.TestCheckResourceAttr("data.opensearch_index_template.test", "id", "terraform-test-ds-index-template"),
+	resource.TestMatchResourceAttr("data.opensearch_index_template.test", "body", regexp.MustCompile(`"index_patterns":\["terraform-test-ds-index-template-\*"\]`)),
+	resource.TestMatchResourceAttr("data.opensearch_index_template.test", "body", regexp.MustCompile(`"number_of_shards":"1"`)),
+)
+
+var testAccOpensearchDataSourceIndexTemplate = `
+resource "opensearch_index_template" "test" {
+  name = "terraform-test-ds-index-template"
+  body = jsonencode({
+    index_patterns = ["terraform-test-ds-index-template-*"]
+    template = {
+      settings = {
+        index = {
+          number_of_shards = "1"
+        }
+      }
+    }
+  })
+}
+
+data "opensearch_index_template" "test" {
+  name = opensearch_index_template.test.name
+}
+`
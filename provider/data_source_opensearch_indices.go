@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func dataSourceOpensearchIndices() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_indices` can be used to list the indices matching a pattern, including the ones not managed by Terraform such as indices rolled over by ISM, with their stats, aliases and settings.",
+		ReadContext: dataSourceOpensearchIndicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "*",
+				Description: "Comma separated list of indices, aliases or wildcards to list. An index or alias which doesn't exist fails the read, a wildcard matching nothing doesn't.",
+			},
+			"expand_wildcards": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "open",
+				Description: "Comma separated list of the kinds of indices wildcards match, among `open`, `closed`, `hidden`, `all` and `none`.",
+			},
+			"settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the settings to return for each index, wildcards allowed, e.g. `index.plugins.index_state_management.*`. Defaults to all the settings.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "the names of the indices, sorted",
+			},
+			"indices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "the indices, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the name of the index",
+						},
+						"uuid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the UUID of the index",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the health of the index, `green`, `yellow` or `red`, empty if it is closed",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`open` or `close`",
+						},
+						"number_of_shards": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "the number of primary shards",
+						},
+						"number_of_replicas": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "the number of replicas of each primary shard",
+						},
+						"docs_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "the number of documents, 0 if the index is closed",
+						},
+						"store_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "the size of the primary and replica shards, in bytes, 0 if the index is closed",
+						},
+						"creation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "when the index was created, in RFC 3339 format",
+						},
+						"aliases": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the aliases of the index, sorted",
+						},
+						"settings": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the flat settings of the index selected by `settings`, lists encoded as JSON",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// catIndicesRow is a row of `_cat/indices`, whose values are strings, or
+// null for the stats of closed indices.
+type catIndicesRow struct {
+	Index        string `json:"index"`
+	UUID         string `json:"uuid"`
+	Health       string `json:"health"`
+	Status       string `json:"status"`
+	Pri          string `json:"pri"`
+	Rep          string `json:"rep"`
+	DocsCount    string `json:"docs.count"`
+	StoreSize    string `json:"store.size"`
+	CreationDate string `json:"creation.date"`
+}
+
+func dataSourceOpensearchIndicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	index := d.Get("index").(string)
+	expandWildcards := d.Get("expand_wildcards").(string)
+	escapedIndex := url.PathEscape(index)
+
+	var rows []catIndicesRow
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   "/_cat/indices/" + escapedIndex,
+		Params: url.Values{
+			"format":           []string{"json"},
+			"bytes":            []string{"b"},
+			"expand_wildcards": []string{expandWildcards},
+			"h":                []string{"index,uuid,health,status,pri,rep,docs.count,store.size,creation.date"},
+		},
+	})
+	if err != nil {
+		return diag.Errorf("error listing the indices %s: %s", index, err)
+	}
+	if err := json.Unmarshal(res.Body, &rows); err != nil {
+		return diag.Errorf("error unmarshalling _cat/indices: %s", err)
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Index < rows[j].Index })
+
+	aliases := map[string]struct {
+		Aliases map[string]interface{} `json:"aliases"`
+	}{}
+	settings := map[string]*elastic7.IndicesGetSettingsResponse{}
+	if len(rows) > 0 {
+		res, err = osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+			Method: "GET",
+			Path:   "/" + escapedIndex + "/_alias",
+			Params: url.Values{"expand_wildcards": []string{expandWildcards}},
+		})
+		if err != nil {
+			return diag.Errorf("error getting the aliases of the indices %s: %s", index, err)
+		}
+		if err := json.Unmarshal(res.Body, &aliases); err != nil {
+			return diag.Errorf("error unmarshalling the aliases of the indices %s: %s", index, err)
+		}
+
+		settings, err = osClient.IndexGetSettings(index).
+			Name(expandStringList(d.Get("settings").([]interface{}))...).
+			ExpandWildcards(expandWildcards).
+			FlatSettings(true).
+			Do(ctx)
+		if err != nil {
+			return diag.Errorf("error getting the settings of the indices %s: %s", index, err)
+		}
+	}
+
+	names := make([]string, 0, len(rows))
+	indices := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		var indexAliases []string
+		for alias := range aliases[row.Index].Aliases {
+			indexAliases = append(indexAliases, alias)
+		}
+		sort.Strings(indexAliases)
+
+		indexSettings := map[string]string{}
+		if s, ok := settings[row.Index]; ok {
+			for key, value := range s.Settings {
+				indexSettings[key] = indicesSettingString(value)
+			}
+		}
+
+		creationDate := ""
+		if millis, err := strconv.ParseInt(row.CreationDate, 10, 64); err == nil {
+			creationDate = time.UnixMilli(millis).UTC().Format(time.RFC3339)
+		}
+
+		names = append(names, row.Index)
+		indices = append(indices, map[string]interface{}{
+			"name":               row.Index,
+			"uuid":               row.UUID,
+			"health":             row.Health,
+			"status":             row.Status,
+			"number_of_shards":   atoiOrZero(row.Pri),
+			"number_of_replicas": atoiOrZero(row.Rep),
+			"docs_count":         atoiOrZero(row.DocsCount),
+			"store_size":         atoiOrZero(row.StoreSize),
+			"creation_date":      creationDate,
+			"aliases":            indexAliases,
+			"settings":           indexSettings,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", index, expandWildcards))
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("indices", indices)
+
+	return diag.FromErr(ds.err)
+}
+
+// indicesSettingString returns the value of a flat setting as a string,
+// lists of values encoded as JSON.
+func indicesSettingString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// atoiOrZero parses a number of `_cat/indices`, which is empty for the stats
+// of closed indices.
+func atoiOrZero(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceIndices_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceIndices,
+				Check:  testCheckOpensearchDataSourceIndices,
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceIndices(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceIndices,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckOpensearchDataSourceIndices,
+					resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.health", "green"),
+					resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.docs_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestOpensearchDataSourceIndicesRead(t *testing.T) {
+	server := fakeopensearch.New(t)
+	testUnitRequest(t, server, http.MethodPut, "/terraform-test-open", `{"settings":{"index":{"number_of_replicas":0}}}`)
+	testUnitRequest(t, server, http.MethodPut, "/terraform-test-open/_doc/1", `{"message":"hello"}`)
+	testUnitRequest(t, server, http.MethodPut, "/terraform-test-closed", `{"settings":{"index":{"number_of_replicas":0}}}`)
+	testUnitRequest(t, server, http.MethodPost, "/terraform-test-closed/_close", ``)
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		want   map[string]string
+	}{
+		{
+			name:   "closed index without stats",
+			config: map[string]interface{}{"index": "terraform-test-*", "expand_wildcards": "all"},
+			want: map[string]string{
+				"names.#":              "2",
+				"indices.0.name":       "terraform-test-closed",
+				"indices.0.status":     "close",
+				"indices.0.docs_count": "0",
+				"indices.0.store_size": "0",
+				"indices.1.name":       "terraform-test-open",
+				"indices.1.status":     "open",
+				"indices.1.docs_count": "1",
+			},
+		},
+		{
+			name:   "no matching index",
+			config: map[string]interface{}{"index": "terraform-test-missing-*"},
+			want:   map[string]string{"id": "terraform-test-missing-*:open", "names.#": "0", "indices.#": "0"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes, diags := testUnitReadDataSource(t, server, nil, "opensearch_indices", tc.config)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			for key, value := range tc.want {
+				if attributes[key] != value {
+					t.Errorf("%s: got %q, want %q", key, attributes[key], value)
+				}
+			}
+		})
+	}
+}
+
+var testCheckOpensearchDataSourceIndices = resource.ComposeAggregateTestCheckFunc(
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "names.#", "2"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "names.0", "terraform-test-indices-000001"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.#", "2"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.name", "terraform-test-indices-000001"),
+	resource.TestCheckResourceAttrSet("data.opensearch_indices.test", "indices.0.uuid"),
+	resource.TestCheckResourceAttrSet("data.opensearch_indices.test", "indices.0.creation_date"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.status", "open"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.number_of_shards", "1"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.number_of_replicas", "0"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.aliases.#", "1"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.aliases.0", "terraform-test-indices"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.settings.%", "1"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.0.settings.index.number_of_replicas", "0"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.1.name", "terraform-test-indices-000002"),
+	resource.TestCheckResourceAttr("data.opensearch_indices.test", "indices.1.aliases.#", "0"),
+)
+
+var testAccOpensearchDataSourceIndices = `
+resource "opensearch_index" "first" {
+  name               = "terraform-test-indices-000001"
+  number_of_shards   = 1
+  number_of_replicas = 0
+  aliases = jsonencode({
+    "terraform-test-indices" = {}
+  })
+}
+
+resource "opensearch_index" "second" {
+  name               = "terraform-test-indices-000002"
+  number_of_shards   = 1
+  number_of_replicas = 0
+}
+
+data "opensearch_indices" "test" {
+  index    = "terraform-test-indices-*"
+  settings = ["index.number_of_replicas"]
+
+  depends_on = [opensearch_index.first, opensearch_index.second]
+}
+`
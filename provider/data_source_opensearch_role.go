@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchRole() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_role` can be used to look up a security role, including the built-in ones such as `all_access` which can't be managed by Terraform.",
+		ReadContext: dataSourceOpensearchRoleRead,
+		Schema:      dataSourceSecurityObjectSchema(openDistroRoleSchema, "role_name"),
+	}
+}
+
+func dataSourceOpensearchRoles() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_roles` can be used to list the security roles whose names match a regular expression.",
+		ReadContext: dataSourceOpensearchRolesRead,
+		Schema:      dataSourceSecurityObjectsSchema(openDistroRoleSchema, "roles", "roles"),
+	}
+}
+
+func dataSourceOpensearchRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("role_name").(string)
+	var role roleEntry
+	if err := getSecurityObject(ctx, m, "roles", name, &role); err != nil {
+		return securityObjectReadError("role", name, err)
+	}
+
+	return setSecurityObject(d, name, flattenRole(name, role))
+}
+
+func dataSourceOpensearchRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	names, objects, err := listSecurityObjects(ctx, d, m, "roles")
+	if err != nil {
+		return diag.Errorf("error listing the roles: %s", err)
+	}
+
+	roles := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		var role roleEntry
+		if err := json.Unmarshal(objects[name], &role); err != nil {
+			return diag.Errorf("error unmarshalling role %s: %s", name, err)
+		}
+		roles = append(roles, flattenRole(name, role))
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("roles", roles)
+
+	return diag.FromErr(ds.err)
+}
+
+// roleEntry is a role as read by the data sources, with its flags.
+type roleEntry struct {
+	RoleBody
+	SecurityObjectFlags
+}
+
+func flattenRole(name string, role roleEntry) map[string]interface{} {
+	return role.SecurityObjectFlags.flatten(map[string]interface{}{
+		"role_name":           name,
+		"description":         role.Description,
+		"cluster_permissions": flattenStringSet(role.ClusterPermissions),
+		"index_permissions":   flattenIndexPermissions(role.IndexPermissions, nil),
+		"tenant_permissions":  flattenTenantPermissions(role.TenantPermissions),
+	})
+}
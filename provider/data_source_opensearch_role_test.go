@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceRole_basic(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
+	if diags.HasError() {
+		t.Skipf("err: %#v", diags)
+	}
+
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckOpensearchRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceRole(randomName),
+				Check:  testCheckOpensearchDataSourceRole(randomName),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceRole(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_role", "/_plugins/_security/api/roles/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceRole(randomName),
+				Check:  testCheckOpensearchDataSourceRole(randomName),
+			},
+		},
+	})
+}
+
+func testCheckOpensearchDataSourceRole(name string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("data.opensearch_role.test", "id", name),
+		resource.TestCheckResourceAttr("data.opensearch_role.test", "description", "test"),
+		resource.TestCheckResourceAttr("data.opensearch_role.test", "cluster_permissions.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_role.test", "index_permissions.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_role.test", "reserved", "false"),
+		resource.TestCheckResourceAttr("data.opensearch_role.all_access", "reserved", "true"),
+		resource.TestCheckResourceAttr("data.opensearch_role.all_access", "static", "true"),
+		resource.TestCheckTypeSetElemAttr("data.opensearch_role.all_access", "cluster_permissions.*", "*"),
+		resource.TestCheckResourceAttr("data.opensearch_roles.test", "names.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_roles.test", "names.0", name),
+		resource.TestCheckResourceAttr("data.opensearch_roles.test", "roles.0.role_name", name),
+		resource.TestCheckResourceAttr("data.opensearch_roles.test", "roles.0.description", "test"),
+	)
+}
+
+func testAccOpensearchDataSourceRole(name string) string {
+	return fmt.Sprintf(`
+resource "opensearch_role" "test" {
+  role_name   = "%s"
+  description = "test"
+
+  cluster_permissions = ["cluster_composite_ops"]
+
+  index_permissions {
+    index_patterns  = ["logs-*"]
+    allowed_actions = ["read"]
+  }
+}
+
+data "opensearch_role" "test" {
+  role_name = opensearch_role.test.role_name
+}
+
+data "opensearch_role" "all_access" {
+  role_name = "all_access"
+}
+
+data "opensearch_roles" "test" {
+  name_regex = "^${opensearch_role.test.role_name}$"
+}
+`, name)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchRolesMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_roles_mapping` can be used to look up the mapping of the backend roles, users and hosts to a security role.",
+		ReadContext: dataSourceOpensearchRolesMappingRead,
+		Schema:      dataSourceSecurityObjectSchema(openDistroRolesMappingSchema, "role_name"),
+	}
+}
+
+func dataSourceOpensearchRolesMappings() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_roles_mappings` can be used to list the roles mappings whose role names match a regular expression.",
+		ReadContext: dataSourceOpensearchRolesMappingsRead,
+		Schema:      dataSourceSecurityObjectsSchema(openDistroRolesMappingSchema, "roles mappings", "roles_mappings"),
+	}
+}
+
+func dataSourceOpensearchRolesMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("role_name").(string)
+	var mapping rolesMappingEntry
+	if err := getSecurityObject(ctx, m, "rolesmapping", name, &mapping); err != nil {
+		return securityObjectReadError("roles mapping", name, err)
+	}
+
+	return setSecurityObject(d, name, flattenRolesMapping(name, mapping))
+}
+
+func dataSourceOpensearchRolesMappingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	names, objects, err := listSecurityObjects(ctx, d, m, "rolesmapping")
+	if err != nil {
+		return diag.Errorf("error listing the roles mappings: %s", err)
+	}
+
+	mappings := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		var mapping rolesMappingEntry
+		if err := json.Unmarshal(objects[name], &mapping); err != nil {
+			return diag.Errorf("error unmarshalling roles mapping %s: %s", name, err)
+		}
+		mappings = append(mappings, flattenRolesMapping(name, mapping))
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("roles_mappings", mappings)
+
+	return diag.FromErr(ds.err)
+}
+
+// rolesMappingEntry is a roles mapping as read by the data sources, with its
+// flags.
+type rolesMappingEntry struct {
+	RolesMapping
+	SecurityObjectFlags
+}
+
+func flattenRolesMapping(name string, mapping rolesMappingEntry) map[string]interface{} {
+	return mapping.SecurityObjectFlags.flatten(map[string]interface{}{
+		"role_name":         name,
+		"description":       mapping.Description,
+		"backend_roles":     flattenStringSet(mapping.BackendRoles),
+		"and_backend_roles": flattenStringSet(mapping.AndBackendRoles),
+		"hosts":             flattenStringSet(mapping.Hosts),
+		"users":             flattenStringSet(mapping.Users),
+	})
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceRolesMapping_basic(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
+	if diags.HasError() {
+		t.Skipf("err: %#v", diags)
+	}
+
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckOpensearchRolesMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceRolesMapping(randomName),
+				Check:  testCheckOpensearchDataSourceRolesMapping(randomName),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceRolesMapping(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_roles_mapping", "/_plugins/_security/api/rolesmapping/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceRolesMapping(randomName),
+				Check:  testCheckOpensearchDataSourceRolesMapping(randomName),
+			},
+		},
+	})
+}
+
+func testCheckOpensearchDataSourceRolesMapping(name string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("data.opensearch_roles_mapping.test", "id", name),
+		resource.TestCheckResourceAttr("data.opensearch_roles_mapping.test", "description", "test"),
+		resource.TestCheckTypeSetElemAttr("data.opensearch_roles_mapping.test", "backend_roles.*", "active_directory"),
+		resource.TestCheckTypeSetElemAttr("data.opensearch_roles_mapping.test", "users.*", "jdoe"),
+		resource.TestCheckResourceAttr("data.opensearch_roles_mapping.test", "reserved", "false"),
+		resource.TestCheckResourceAttr("data.opensearch_roles_mappings.test", "names.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_roles_mappings.test", "roles_mappings.0.role_name", name),
+		resource.TestCheckResourceAttr("data.opensearch_roles_mappings.test", "roles_mappings.0.users.#", "1"),
+	)
+}
+
+func testAccOpensearchDataSourceRolesMapping(name string) string {
+	return fmt.Sprintf(`
+resource "opensearch_role" "test" {
+  role_name = "%s"
+}
+
+resource "opensearch_roles_mapping" "test" {
+  role_name     = opensearch_role.test.role_name
+  description   = "test"
+  backend_roles = ["active_directory"]
+  users         = ["jdoe"]
+}
+
+data "opensearch_roles_mapping" "test" {
+  role_name = opensearch_roles_mapping.test.role_name
+}
+
+data "opensearch_roles_mappings" "test" {
+  name_regex = "^${opensearch_roles_mapping.test.role_name}$"
+}
+`, name)
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+// SecurityObjectFlags are returned by the security plugin with its objects.
+// They aren't part of the bodies the resources send and compute their etag
+// from: the data sources read objects into entry types embedding both.
+type SecurityObjectFlags struct {
+	Reserved bool `json:"reserved"`
+	Hidden   bool `json:"hidden"`
+	Static   bool `json:"static"`
+}
+
+func (f SecurityObjectFlags) flatten(object map[string]interface{}) map[string]interface{} {
+	object["reserved"] = f.Reserved
+	object["hidden"] = f.Hidden
+	object["static"] = f.Static
+	return object
+}
+
+// dataSourceSecurityObjectSchema returns the schema of the data source
+// looking up a security object by nameKey, made of the attributes of the
+// resource managing such objects.
+func dataSourceSecurityObjectSchema(resourceSchema map[string]*schema.Schema, nameKey string) map[string]*schema.Schema {
+	s := securityObjectSchema(resourceSchema)
+	s[nameKey].Computed = false
+	s[nameKey].Required = true
+	return s
+}
+
+// dataSourceSecurityObjectsSchema returns the schema of the data source
+// listing the security objects of a kind, as a list attribute named listKey.
+func dataSourceSecurityObjectsSchema(resourceSchema map[string]*schema.Schema, kind, listKey string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsValidRegExp,
+			Description:  fmt.Sprintf("Regular expression the names of the %s must match, e.g. `^kibana_`. Defaults to all of them.", kind),
+		},
+		"names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: fmt.Sprintf("the names of the %s, sorted", kind),
+		},
+		listKey: {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: fmt.Sprintf("the %s, sorted by name", kind),
+			Elem:        &schema.Resource{Schema: securityObjectSchema(resourceSchema)},
+		},
+	}
+}
+
+// securityObjectSchema returns the computed attributes of a security object
+// from the schema of its resource, without the etag and the secrets, nor the
+// cluster argument withCluster adds to the resource schema.
+func securityObjectSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"reserved": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "whether the object is reserved, i.e. can only be changed by an admin using the admin certificate",
+		},
+		"hidden": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "whether the object is hidden, i.e. only visible to an admin using the admin certificate",
+		},
+		"static": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "whether the object is built into the security plugin, in which case it can't be changed",
+		},
+	}
+	for key, attribute := range resourceSchema {
+		if key == "etag" || key == "cluster" || attribute.Sensitive {
+			continue
+		}
+		s[key] = computedSchema(attribute)
+	}
+	return s
+}
+
+func computedSchema(attribute *schema.Schema) *schema.Schema {
+	s := &schema.Schema{
+		Type:        attribute.Type,
+		Computed:    true,
+		Elem:        attribute.Elem,
+		Set:         attribute.Set,
+		Description: attribute.Description,
+	}
+	if elem, ok := attribute.Elem.(*schema.Resource); ok {
+		nested := make(map[string]*schema.Schema, len(elem.Schema))
+		for key, a := range elem.Schema {
+			nested[key] = computedSchema(a)
+		}
+		s.Elem = &schema.Resource{Schema: nested}
+	}
+	return s
+}
+
+// securityObjectReadError returns the diagnostics of the error of the lookup
+// of a security object.
+func securityObjectReadError(kind, name string, err error) diag.Diagnostics {
+	if isNotFound(err) {
+		return diag.Errorf("%s %q not found", kind, name)
+	}
+	return diag.Errorf("error getting the %s %q: %s", kind, name, err)
+}
+
+// setSecurityObject sets the attributes of the data source of a security
+// object to the ones of object.
+func setSecurityObject(d *schema.ResourceData, name string, object map[string]interface{}) diag.Diagnostics {
+	d.SetId(name)
+
+	ds := &resourceDataSetter{d: d}
+	for key, value := range object {
+		ds.set(key, value)
+	}
+	return diag.FromErr(ds.err)
+}
+
+// getSecurityObject reads the security object name of kind, the path segment
+// of the REST API, into object.
+func getSecurityObject(ctx context.Context, m interface{}, kind, name string, object interface{}) error {
+	path, err := uritemplates.Expand("/_plugins/_security/api/{kind}/{name}", map[string]string{
+		"kind": kind,
+		"name": name,
+	})
+	if err != nil {
+		return fmt.Errorf("error building URL path for %s: %+v", kind, err)
+	}
+
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return err
+	}
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return err
+	}
+	var objects map[string]json.RawMessage
+	if err := json.Unmarshal(res.Body, &objects); err != nil {
+		return fmt.Errorf("error unmarshalling %s: %+v", kind, err)
+	}
+	raw, ok := objects[name]
+	if !ok {
+		return fmt.Errorf("%s %q: %w", kind, name, ErrNotFound)
+	}
+	return json.Unmarshal(raw, object)
+}
+
+// listSecurityObjects returns the names of the security objects of kind, the
+// path segment of the REST API, which match the name_regex of d, sorted, and
+// the objects by name.
+func listSecurityObjects(ctx context.Context, d *schema.ResourceData, m interface{}, kind string) ([]string, map[string]json.RawMessage, error) {
+	path, err := uritemplates.Expand("/_plugins/_security/api/{kind}", map[string]string{
+		"kind": kind,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building URL path for %s: %+v", kind, err)
+	}
+
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var objects map[string]json.RawMessage
+	if err := json.Unmarshal(res.Body, &objects); err != nil {
+		return nil, nil, fmt.Errorf("error unmarshalling %s: %+v", kind, err)
+	}
+
+	nameRegex := d.Get("name_regex").(string)
+	re, err := regexp.Compile(nameRegex)
+	if err != nil {
+		return nil, nil, err
+	}
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		if re.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	d.SetId(fmt.Sprintf("%s:%s", kind, nameRegex))
+	return names, objects, nil
+}
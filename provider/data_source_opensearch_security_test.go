@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestOpensearchDataSourceSecurityObjectsRead(t *testing.T) {
+	server := fakeopensearch.New(t)
+
+	cases := []struct {
+		dataSource string
+		listKey    string
+		nameRegex  string
+		want       []string
+	}{
+		{dataSource: "opensearch_roles", listKey: "roles", want: []string{"all_access", "kibana_user"}},
+		{dataSource: "opensearch_roles", listKey: "roles", nameRegex: "^kibana_", want: []string{"kibana_user"}},
+		{dataSource: "opensearch_roles", listKey: "roles", nameRegex: "^terraform-", want: nil},
+		{dataSource: "opensearch_roles_mappings", listKey: "roles_mappings", nameRegex: "^terraform-", want: nil},
+		{dataSource: "opensearch_users", listKey: "users", nameRegex: "^terraform-", want: nil},
+		{dataSource: "opensearch_action_groups", listKey: "action_groups", nameRegex: "^terraform-", want: nil},
+		{dataSource: "opensearch_dashboard_tenants", listKey: "tenants", nameRegex: "^terraform-", want: nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.dataSource+" "+tc.nameRegex, func(t *testing.T) {
+			attributes, diags := testUnitReadDataSource(t, server, nil, tc.dataSource, map[string]interface{}{"name_regex": tc.nameRegex})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if attributes["id"] == "" {
+				t.Errorf("expected an ID even without matching objects")
+			}
+			var names []string
+			for i := 0; attributes["names."+strconv.Itoa(i)] != ""; i++ {
+				names = append(names, attributes["names."+strconv.Itoa(i)])
+			}
+			if attributes["names.#"] != strconv.Itoa(len(tc.want)) || attributes[tc.listKey+".#"] != strconv.Itoa(len(tc.want)) || strings.Join(names, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("got names %v (%s %s), want %v", names, attributes["names.#"], attributes[tc.listKey+".#"], tc.want)
+			}
+		})
+	}
+}
+
+func TestOpensearchDataSourceSecurityObjectsInvalidRegex(t *testing.T) {
+	server := fakeopensearch.New(t)
+
+	_, diags := testUnitReadDataSource(t, server, nil, "opensearch_roles", map[string]interface{}{"name_regex": "["})
+	if !diags.HasError() {
+		t.Errorf("expected an invalid name_regex to be rejected")
+	}
+}
+
+func TestSecurityObjectFlagsStayOutOfBodies(t *testing.T) {
+	const flags = `"reserved":true,"hidden":true,"static":true,`
+	cases := []struct {
+		kind   string
+		object string
+		body   func() interface{}
+		entry  func() interface{}
+	}{
+		{"role", `{"description":"test","cluster_permissions":["cluster_monitor"]}`, func() interface{} { return &RoleBody{} }, func() interface{} { return &roleEntry{} }},
+		{"user", `{"description":"test","backend_roles":["admin"],"attributes":{}}`, func() interface{} { return &UserBody{} }, func() interface{} { return &userEntry{} }},
+		{"roles mapping", `{"description":"test","users":["jdoe"]}`, func() interface{} { return &RolesMapping{} }, func() interface{} { return &rolesMappingEntry{} }},
+		{"tenant", `{"description":"test"}`, func() interface{} { return &TenantBody{} }, func() interface{} { return &tenantEntry{} }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.kind, func(t *testing.T) {
+			withFlags := "{" + flags + tc.object[1:]
+
+			body, plain := tc.body(), tc.body()
+			if err := json.Unmarshal([]byte(withFlags), body); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.object), plain); err != nil {
+				t.Fatal(err)
+			}
+			sent, _ := json.Marshal(body)
+			if strings.Contains(string(sent), "reserved") || strings.Contains(string(sent), "static") {
+				t.Errorf("expected the flags to stay out of the request body, got %s", sent)
+			}
+			etag, _ := objectETag(body)
+			plainETag, _ := objectETag(plain)
+			if etag != plainETag {
+				t.Errorf("expected the flags not to change the etag")
+			}
+
+			entry := tc.entry()
+			if err := json.Unmarshal([]byte(withFlags), entry); err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := json.Marshal(entry); !strings.Contains(string(got), flags[:len(flags)-1]) {
+				t.Errorf("expected the entry to keep the flags, got %s", got)
+			}
+		})
+	}
+}
+
+func TestOpensearchDataSourceSecurityObjectReadFlags(t *testing.T) {
+	server := fakeopensearch.New(t)
+
+	attributes, diags := testUnitReadDataSource(t, server, nil, "opensearch_role", map[string]interface{}{"role_name": "all_access"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if attributes["reserved"] != "true" {
+		t.Errorf("expected the built-in role to be reserved, got %q", attributes["reserved"])
+	}
+
+	_, diags = testUnitReadDataSource(t, server, nil, "opensearch_role", map[string]interface{}{"role_name": "terraform-missing"})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `role "terraform-missing" not found`) {
+		t.Errorf("expected a missing role to be reported, got %v", diags)
+	}
+}
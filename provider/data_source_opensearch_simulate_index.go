@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/olivere/elastic/uritemplates"
+	elastic7 "github.com/olivere/elastic/v7"
+)
+
+func dataSourceOpensearchSimulateIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_simulate_index` can be used to preview the settings, mappings and aliases an index would be created with, i.e. the ones of the matching composable index template with the highest priority merged with its component templates, without creating it.",
+		ReadContext: dataSourceOpensearchSimulateIndexRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the index to simulate, which doesn't need to exist.",
+			},
+			"settings": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "the flat settings the index would get, e.g. `index.number_of_shards`, lists encoded as JSON",
+			},
+			"mappings": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the JSON mappings the index would get",
+			},
+			"aliases": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "the JSON aliases the index would get, by name",
+			},
+			"overlapping": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "the other index templates matching the index, overridden by the one with the highest priority",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "the name of the index template",
+						},
+						"index_patterns": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "the index patterns of the index template",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// simulateIndexResponse is the response of `_simulate_index`, an empty object
+// if no index template matches.
+type simulateIndexResponse struct {
+	Template struct {
+		Settings map[string]interface{} `json:"settings"`
+		Mappings map[string]interface{} `json:"mappings"`
+		Aliases  map[string]interface{} `json:"aliases"`
+	} `json:"template"`
+	Overlapping []struct {
+		Name          string   `json:"name"`
+		IndexPatterns []string `json:"index_patterns"`
+	} `json:"overlapping"`
+}
+
+func dataSourceOpensearchSimulateIndexRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	osClient, err := getClient(m.(*ProviderConf))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("name").(string)
+	path, err := uritemplates.Expand("/_index_template/_simulate_index/{name}", map[string]string{
+		"name": name,
+	})
+	if err != nil {
+		return diag.Errorf("error building URL path for index simulation: %+v", err)
+	}
+
+	res, err := osClient.PerformRequest(ctx, elastic7.PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+	})
+	if err != nil {
+		return diag.Errorf("error simulating the index %s: %s", name, err)
+	}
+	var simulation simulateIndexResponse
+	if err := json.Unmarshal(res.Body, &simulation); err != nil {
+		return diag.Errorf("error unmarshalling the simulation of the index %s: %s", name, err)
+	}
+
+	settings := map[string]string{}
+	for key, value := range flattenMap(simulation.Template.Settings) {
+		settings[key] = indicesSettingString(value)
+	}
+	mappings, err := jsonObjectString(simulation.Template.Mappings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	aliases, err := jsonObjectString(simulation.Template.Aliases)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	overlapping := make([]map[string]interface{}, 0, len(simulation.Overlapping))
+	for _, o := range simulation.Overlapping {
+		overlapping = append(overlapping, map[string]interface{}{
+			"name":           o.Name,
+			"index_patterns": o.IndexPatterns,
+		})
+	}
+
+	d.SetId(name)
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("settings", settings)
+	ds.set("mappings", mappings)
+	ds.set("aliases", aliases)
+	ds.set("overlapping", overlapping)
+
+	return diag.FromErr(ds.err)
+}
+
+// jsonObjectString returns object as JSON, `{}` if it is missing.
+func jsonObjectString(object map[string]interface{}) (string, error) {
+	if object == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceSimulateIndex_basic(t *testing.T) {
+	var providers []*schema.Provider
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviderFactories(&providers),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceSimulateIndex,
+				Check:  testCheckOpensearchDataSourceSimulateIndex,
+			},
+		},
+	})
+}
+
+func TestOpensearchDataSourceSimulateIndexRead(t *testing.T) {
+	server := fakeopensearch.New(t)
+	testUnitRequest(t, server, http.MethodPut, "/_index_template/terraform-test-logs", `{"index_patterns":["logs-*"],"priority":10,"template":{"settings":{"index":{"number_of_shards":2}},"aliases":{"logs":{}}}}`)
+	testUnitRequest(t, server, http.MethodPut, "/_index_template/terraform-test-logs-default", `{"index_patterns":["logs-*"],"priority":1}`)
+
+	cases := []struct {
+		name  string
+		index string
+		want  map[string]string
+	}{
+		{
+			name:  "matching template",
+			index: "logs-2024",
+			want: map[string]string{
+				"settings.index.number_of_shards": "2",
+				"aliases":                         `{"logs":{}}`,
+				"mappings":                        "{}",
+				"overlapping.#":                   "1",
+				"overlapping.0.name":              "terraform-test-logs-default",
+			},
+		},
+		{
+			name:  "no matching template",
+			index: "metrics-2024",
+			want: map[string]string{
+				"id":            "metrics-2024",
+				"settings.%":    "0",
+				"mappings":      "{}",
+				"aliases":       "{}",
+				"overlapping.#": "0",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attributes, diags := testUnitReadDataSource(t, server, nil, "opensearch_simulate_index", map[string]interface{}{"name": tc.index})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			for key, value := range tc.want {
+				if attributes[key] != value {
+					t.Errorf("%s: got %q, want %q", key, attributes[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestUnitOpensearchDataSourceSimulateIndex(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceSimulateIndex,
+				Check:  testCheckOpensearchDataSourceSimulateIndex,
+			},
+		},
+	})
+}
+
+var testCheckOpensearchDataSourceSimulateIndex = resource.ComposeAggregateTestCheckFunc(
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "id", "terraform-test-simulate-000001"),
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "settings.index.number_of_shards", "2"),
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "mappings", `{"properties":{"message":{"type":"text"}}}`),
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "aliases", `{"terraform-test-simulate":{}}`),
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "overlapping.#", "1"),
+	resource.TestCheckResourceAttr("data.opensearch_simulate_index.test", "overlapping.0.name", "terraform-test-simulate-fallback"),
+)
+
+var testAccOpensearchDataSourceSimulateIndex = `
+resource "opensearch_component_template" "mappings" {
+  name = "terraform-test-simulate-mappings"
+  body = jsonencode({
+    template = {
+      mappings = {
+        properties = {
+          message = { type = "text" }
+        }
+      }
+    }
+  })
+}
+
+resource "opensearch_composable_index_template" "test" {
+  name = "terraform-test-simulate"
+  body = jsonencode({
+    index_patterns = ["terraform-test-simulate-*"]
+    priority       = 200
+    composed_of    = [opensearch_component_template.mappings.name]
+    template = {
+      settings = {
+        index = {
+          number_of_shards = "2"
+        }
+      }
+      aliases = {
+        "terraform-test-simulate" = {}
+      }
+    }
+  })
+}
+
+resource "opensearch_composable_index_template" "fallback" {
+  name = "terraform-test-simulate-fallback"
+  body = jsonencode({
+    index_patterns = ["terraform-test-simulate-0*"]
+    priority       = 100
+    template = {
+      settings = {
+        index = {
+          number_of_shards = "1"
+        }
+      }
+    }
+  })
+}
+
+data "opensearch_simulate_index" "test" {
+  name = "terraform-test-simulate-000001"
+
+  depends_on = [
+    opensearch_composable_index_template.test,
+    opensearch_composable_index_template.fallback,
+  ]
+}
+`
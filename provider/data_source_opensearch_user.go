@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceOpensearchUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_user` can be used to look up a user of the internal user database of the security plugin. Its password is never returned.",
+		ReadContext: dataSourceOpensearchUserRead,
+		Schema:      dataSourceSecurityObjectSchema(openDistroUserSchema, "username"),
+	}
+}
+
+func dataSourceOpensearchUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "`opensearch_users` can be used to list the users of the internal user database whose names match a regular expression.",
+		ReadContext: dataSourceOpensearchUsersRead,
+		Schema:      dataSourceSecurityObjectsSchema(openDistroUserSchema, "users", "users"),
+	}
+}
+
+func dataSourceOpensearchUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	name := d.Get("username").(string)
+	var user userEntry
+	if err := getSecurityObject(ctx, m, "internalusers", name, &user); err != nil {
+		return securityObjectReadError("user", name, err)
+	}
+
+	return setSecurityObject(d, name, flattenUser(name, user))
+}
+
+func dataSourceOpensearchUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	names, objects, err := listSecurityObjects(ctx, d, m, "internalusers")
+	if err != nil {
+		return diag.Errorf("error listing the users: %s", err)
+	}
+
+	users := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		var user userEntry
+		if err := json.Unmarshal(objects[name], &user); err != nil {
+			return diag.Errorf("error unmarshalling user %s: %s", name, err)
+		}
+		users = append(users, flattenUser(name, user))
+	}
+
+	ds := &resourceDataSetter{d: d}
+	ds.set("names", names)
+	ds.set("users", users)
+
+	return diag.FromErr(ds.err)
+}
+
+// userEntry is a user as read by the data sources, with its flags.
+type userEntry struct {
+	UserBody
+	SecurityObjectFlags
+}
+
+func flattenUser(name string, user userEntry) map[string]interface{} {
+	return user.SecurityObjectFlags.flatten(map[string]interface{}{
+		"username":      name,
+		"description":   user.Description,
+		"backend_roles": flattenStringAsInterfaceSet(user.BackendRoles),
+		"attributes":    user.Attributes,
+	})
+}
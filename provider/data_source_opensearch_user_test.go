@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestAccOpensearchDataSourceUser_basic(t *testing.T) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
+	if diags.HasError() {
+		t.Skipf("err: %#v", diags)
+	}
+
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccOpendistroProviders,
+		CheckDestroy: testAccCheckOpensearchUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceUser(randomName),
+				Check:  testCheckOpensearchDataSourceUser(randomName),
+			},
+		},
+	})
+}
+
+func TestUnitOpensearchDataSourceUser(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_user", "/_plugins/_security/api/internalusers/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchDataSourceUser(randomName),
+				Check:  testCheckOpensearchDataSourceUser(randomName),
+			},
+		},
+	})
+}
+
+func testCheckOpensearchDataSourceUser(name string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttr("data.opensearch_user.test", "id", name),
+		resource.TestCheckResourceAttr("data.opensearch_user.test", "description", "test"),
+		resource.TestCheckResourceAttr("data.opensearch_user.test", "attributes.team", "search"),
+		resource.TestCheckTypeSetElemAttr("data.opensearch_user.test", "backend_roles.*", "readers"),
+		resource.TestCheckNoResourceAttr("data.opensearch_user.test", "password"),
+		resource.TestCheckResourceAttr("data.opensearch_user.test", "reserved", "false"),
+		resource.TestCheckResourceAttr("data.opensearch_users.test", "names.#", "1"),
+		resource.TestCheckResourceAttr("data.opensearch_users.test", "users.0.username", name),
+		resource.TestCheckResourceAttr("data.opensearch_users.test", "users.0.backend_roles.#", "1"),
+	)
+}
+
+func testAccOpensearchDataSourceUser(name string) string {
+	return fmt.Sprintf(`
+resource "opensearch_user" "test" {
+  username      = "%s"
+  password      = "passw0rd-Terraform"
+  description   = "test"
+  backend_roles = ["readers"]
+
+  attributes = {
+    team = "search"
+  }
+}
+
+data "opensearch_user" "test" {
+  username = opensearch_user.test.username
+}
+
+data "opensearch_users" "test" {
+  name_regex = "^${opensearch_user.test.username}$"
+}
+`, name)
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Endpoints only reading data even though they are called with POST.
+var readOnlyPostEndpoints = []string{
+	"_search",
+	"_msearch",
+	"_count",
+	"_mget",
+	"_explain",
+	"_field_caps",
+	"_validate",
+	"_analyze",
+	"_simulate",
+	"_simulate_index",
+}
+
+// The manifest of every cluster is written to the same file by default, so
+// the transports recording to a file share its writer.
+var (
+	dryRunManifestsMu sync.Mutex
+	dryRunManifests   = map[string]*dryRunManifest{}
+)
+
+// dryRunRun identifies the entries recorded by this provider process, as
+// manifests are appended to across runs.
+var dryRunRun = fmt.Sprintf("%s-%d", time.Now().UTC().Format("20060102T150405Z"), os.Getpid())
+
+// The objects written in dry run mode don't exist in the cluster, or don't
+// have the written content, so the paths written during the run are kept to
+// answer reads of them with dryRunNotReadBack rather than the live object.
+var (
+	dryRunWrittenMu sync.Mutex
+	dryRunWritten   = map[string]bool{}
+)
+
+// dryRunNotReadBack is the reason of the error answering the read of a path
+// written in dry run mode, on which resources keep the planned state.
+const dryRunNotReadBack = "dry run: not reading back an object written in this run"
+
+// dryRunManifest appends the entries of every transport recording to a file,
+// numbered in the order they are written during the run.
+type dryRunManifest struct {
+	path     string
+	mu       sync.Mutex
+	sequence int64
+}
+
+// openDryRunManifest returns the writer of the manifest at path.
+func openDryRunManifest(path string) *dryRunManifest {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	dryRunManifestsMu.Lock()
+	defer dryRunManifestsMu.Unlock()
+
+	m, ok := dryRunManifests[path]
+	if !ok {
+		m = &dryRunManifest{path: path}
+		dryRunManifests[path] = m
+	}
+	return m
+}
+
+// record numbers entry and appends it to the manifest.
+func (m *dryRunManifest) record(entry *dryRunEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry.Sequence = m.sequence + 1
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding the dry run manifest entry: %w", err)
+	}
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening the dry run manifest: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing the dry run manifest: %w", err)
+	}
+	m.sequence = entry.Sequence
+	return nil
+}
+
+// dryRunTransport answers the requests that would change the cluster with a
+// synthetic success instead of sending them, and records them in a JSON lines
+// manifest. Read requests are sent to the cluster.
+type dryRunTransport struct {
+	rt          http.RoundTripper
+	manifest    *dryRunManifest
+	redactPaths []string
+	now         func() time.Time
+}
+
+// dryRunEntry is a line of the manifest.
+type dryRunEntry struct {
+	Run      string      `json:"run"`
+	Sequence int64       `json:"sequence"`
+	Time     time.Time   `json:"time"`
+	Method   string      `json:"method"`
+	Host     string      `json:"host"`
+	Path     string      `json:"path"`
+	Query    string      `json:"query,omitempty"`
+	Body     interface{} `json:"body,omitempty"`
+}
+
+func newDryRunTransport(rt http.RoundTripper, manifest string, redactPaths []string) *dryRunTransport {
+	return &dryRunTransport{
+		rt:          rt,
+		manifest:    openDryRunManifest(manifest),
+		redactPaths: redactPaths,
+		now:         time.Now,
+	}
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingRequest(req) {
+		if req.Method == http.MethodGet && dryRunWasWritten(req) {
+			log.Printf("[INFO] Dry run: not reading back %s", req.URL.Path)
+			return dryRunNotReadBackResponse(req), nil
+		}
+		return t.rt.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entry := dryRunEntry{
+		Run:    dryRunRun,
+		Time:   t.now().UTC(),
+		Method: req.Method,
+		Host:   req.URL.Host,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Body:   manifestBody(body, t.redactPaths),
+	}
+	if err := t.manifest.record(&entry); err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] Dry run: not sending %s %s", req.Method, req.URL.Path)
+	if req.Method != http.MethodDelete {
+		dryRunWrittenMu.Lock()
+		dryRunWritten[dryRunWrittenKey(req.URL.Host, req.URL.Path)] = true
+		dryRunWrittenMu.Unlock()
+	}
+
+	return dryRunResponse(req, entry), nil
+}
+
+// isMutatingRequest returns whether req would change the cluster.
+func isMutatingRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	case http.MethodPost:
+		for _, segment := range strings.Split(req.URL.Path, "/") {
+			if containsString(readOnlyPostEndpoints, segment) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// dryRunResponse returns the synthetic success answering req. Its `_id`, the
+// last segment of the path or a generated one for a POST, lets resources
+// reading the ID of a created object proceed.
+func dryRunResponse(req *http.Request, entry dryRunEntry) *http.Response {
+	id := path.Base(req.URL.Path)
+	if req.Method == http.MethodPost || strings.HasPrefix(id, "_") || id == "/" {
+		id = fmt.Sprintf("dry-run-%d", entry.Sequence)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"acknowledged": true,
+		"dry_run":      true,
+		"_id":          id,
+		"result":       "noop",
+	})
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=UTF-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func dryRunWrittenKey(host, urlPath string) string {
+	return host + path.Clean("/"+urlPath)
+}
+
+// dryRunWasWritten returns whether req reads an object written in dry run
+// mode: a written path or one below it, e.g. the settings of a created index,
+// or an ID generated by dryRunResponse.
+func dryRunWasWritten(req *http.Request) bool {
+	for _, segment := range strings.Split(req.URL.Path, "/") {
+		if strings.HasPrefix(segment, "dry-run-") {
+			return true
+		}
+	}
+
+	dryRunWrittenMu.Lock()
+	defer dryRunWrittenMu.Unlock()
+	for key := dryRunWrittenKey(req.URL.Host, req.URL.Path); strings.Contains(key, "/"); key = key[:strings.LastIndex(key, "/")] {
+		if dryRunWritten[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunNotReadBackResponse returns the error answering the read of req, a
+// path written in dry run mode.
+func dryRunNotReadBackResponse(req *http.Request) *http.Response {
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":   "dry_run_exception",
+			"reason": dryRunNotReadBack,
+		},
+		"status": http.StatusBadRequest,
+	})
+
+	return &http.Response{
+		Status:        "400 Bad Request",
+		StatusCode:    http.StatusBadRequest,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json; charset=UTF-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// withDryRun makes the create, read and update functions of r keep the
+// planned state when they fail reading back an object written in dry run
+// mode, which Terraform would otherwise report as an inconsistent result.
+func withDryRun(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			err := f(d, meta)
+			if err != nil && strings.Contains(err.Error(), dryRunNotReadBack) {
+				log.Printf("[INFO] Dry run: keeping the planned state of %s", d.Id())
+				return nil
+			}
+			return err
+		}
+	}
+	wrapContext := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			var diags diag.Diagnostics
+			for _, diagnostic := range f(ctx, d, meta) {
+				if strings.Contains(diagnostic.Summary, dryRunNotReadBack) || strings.Contains(diagnostic.Detail, dryRunNotReadBack) {
+					log.Printf("[INFO] Dry run: keeping the planned state of %s", d.Id())
+					continue
+				}
+				diags = append(diags, diagnostic)
+			}
+			return diags
+		}
+	}
+
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.CreateContext = wrapContext(r.CreateContext)
+	r.ReadContext = wrapContext(r.ReadContext)
+	r.UpdateContext = wrapContext(r.UpdateContext)
+	return r
+}
+
+// manifestBody returns body, a JSON document or JSON lines, with the secrets
+// redacted as in the request log.
+func manifestBody(body []byte, redactPaths []string) interface{} {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err == nil {
+		return redactValue(doc, "", redactPaths)
+	}
+
+	var docs []interface{}
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(line, &doc); err != nil {
+			return fmt.Sprintf("[%d bytes of non-JSON content omitted]", len(body))
+		}
+		docs = append(docs, redactValue(doc, "", redactPaths))
+	}
+	return docs
+}
@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte(`{"hits":{"total":{"value":0}}}`))
+	}))
+	defer server.Close()
+
+	manifest := filepath.Join(t.TempDir(), "manifest.jsonl")
+	client := &http.Client{Transport: newDryRunTransport(http.DefaultTransport, manifest, []string{"attributes.api_key"})}
+
+	res, err := client.Get(server.URL + "/_plugins/_security/api/roles/my-role")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	res, err = client.Post(server.URL+"/my-index/_search", "application/json", strings.NewReader(`{"query":{"match_all":{}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if hits.Load() != 2 {
+		t.Errorf("expected reads to be sent to the cluster, got %d requests", hits.Load())
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/_plugins/_security/api/internalusers/jdoe?pretty=true", strings.NewReader(`{"password":"secret","attributes":{"api_key":"key","team":"search"}}`))
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || body["_id"] != "jdoe" || body["acknowledged"] != true {
+		t.Errorf("expected a synthetic success, got %d %v", res.StatusCode, body)
+	}
+
+	res, err = client.Get(server.URL + "/_plugins/_security/api/internalusers/jdoe")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected the read of a written object to be answered by the transport, got %d", res.StatusCode)
+	}
+
+	res, err = client.Post(server.URL+"/_bulk", "application/x-ndjson", strings.NewReader("{\"index\":{\"_index\":\"logs\"}}\n{\"message\":\"hello\"}\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+
+	req, _ = http.NewRequest(http.MethodDelete, server.URL+"/my-index", nil)
+	res, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if hits.Load() != 2 {
+		t.Errorf("expected changes not to be sent to the cluster, got %d requests", hits.Load())
+	}
+
+	f, err := os.Open(manifest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	var entries []dryRunEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry dryRunEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid manifest line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 manifest entries, got %d", len(entries))
+	}
+
+	put := entries[0]
+	if put.Run != dryRunRun || put.Sequence != 1 || put.Method != http.MethodPut || put.Path != "/_plugins/_security/api/internalusers/jdoe" || put.Query != "pretty=true" {
+		t.Errorf("unexpected entry %+v", put)
+	}
+	user := put.Body.(map[string]interface{})
+	attributes := user["attributes"].(map[string]interface{})
+	if user["password"] != redactedValue || attributes["api_key"] != redactedValue || attributes["team"] != "search" {
+		t.Errorf("expected secrets to be redacted, got %v", user)
+	}
+
+	if docs, ok := entries[1].Body.([]interface{}); !ok || len(docs) != 2 {
+		t.Errorf("expected the bulk body to be recorded line by line, got %v", entries[1].Body)
+	}
+	if entries[2].Method != http.MethodDelete || entries[2].Body != nil {
+		t.Errorf("unexpected entry %+v", entries[2])
+	}
+}
+
+func TestDryRunTransportSharedManifest(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.jsonl")
+	eu := &http.Client{Transport: newDryRunTransport(http.DefaultTransport, manifest, nil)}
+	us := &http.Client{Transport: newDryRunTransport(http.DefaultTransport, manifest, nil)}
+
+	for _, request := range []struct {
+		client *http.Client
+		url    string
+	}{
+		{eu, "http://eu-1.example:9200/my-index"},
+		{us, "http://us-1.example:9200/my-index"},
+		{eu, "http://eu-1.example:9200/other-index"},
+	} {
+		req, _ := http.NewRequest(http.MethodDelete, request.url, nil)
+		res, err := request.client.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	f, err := os.Open(manifest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry dryRunEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid manifest line %q: %v", scanner.Text(), err)
+		}
+		if entry.Sequence != int64(len(hosts)+1) {
+			t.Errorf("expected the entries of every cluster to be numbered in order, got %d for entry %d", entry.Sequence, len(hosts)+1)
+		}
+		hosts = append(hosts, entry.Host)
+	}
+	if strings.Join(hosts, ",") != "eu-1.example:9200,us-1.example:9200,eu-1.example:9200" {
+		t.Errorf("unexpected manifest hosts %v", hosts)
+	}
+}
+
+func TestDryRunResourceKeepsPlannedState(t *testing.T) {
+	server := fakeopensearch.New(t)
+	testUnitRequest(t, server, http.MethodPut, "/_plugins/_security/api/roles/live", `{"cluster_permissions":["cluster_monitor"]}`)
+
+	manifest := filepath.Join(t.TempDir(), "manifest.jsonl")
+	p := testUnitProvider(t, server, map[string]interface{}{"dry_run": true, "dry_run_manifest": manifest})
+	r := p.ResourcesMap["opensearch_role"]
+
+	created := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"role_name":           "created",
+		"cluster_permissions": []interface{}{"cluster_all"},
+	})
+	if err := r.Create(created, p.Meta()); err != nil {
+		t.Fatalf("unexpected error creating: %v", err)
+	}
+	if err := r.Read(created, p.Meta()); err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if created.Id() != "created" || created.Get("cluster_permissions").(*schema.Set).Len() != 1 {
+		t.Errorf("expected the planned state to be kept, got %q %v", created.Id(), created.Get("cluster_permissions"))
+	}
+
+	live := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"role_name": "live"})
+	live.SetId("live")
+	if err := r.Read(live, p.Meta()); err != nil {
+		t.Fatalf("unexpected error reading: %v", err)
+	}
+	if err := live.Set("cluster_permissions", []interface{}{"cluster_all"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(live, p.Meta()); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+	if permissions := live.Get("cluster_permissions").(*schema.Set); !permissions.Contains("cluster_all") || permissions.Len() != 1 {
+		t.Errorf("expected the planned state to be kept, got %v", permissions.List())
+	}
+
+	res, err := http.Get(server.URL + "/_plugins/_security/api/roles/created")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected the created role not to be sent, got status %d", res.StatusCode)
+	}
+	res, err = http.Get(server.URL + "/_plugins/_security/api/roles/live")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roles map[string]RoleBody
+	if err := json.NewDecoder(res.Body).Decode(&roles); err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if strings.Join(roles["live"].ClusterPermissions, ",") != "cluster_monitor" {
+		t.Errorf("expected the updated role not to be sent, got %v", roles["live"].ClusterPermissions)
+	}
+
+	content, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 2 {
+		t.Errorf("expected the create and the update in the manifest, got %d entries", lines)
+	}
+}
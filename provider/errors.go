@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+// Classes of the errors returned by OpenSearch, to check for with errors.Is
+// once translated by translateError.
+var (
+	// The object doesn't exist: HTTP 404, `resource_not_found_exception` or
+	// `index_not_found_exception`.
+	ErrNotFound = errors.New("not found")
+	// The credentials are invalid or lack a permission: HTTP 401 or 403, or
+	// `security_exception`.
+	ErrSecurity = errors.New("security exception")
+	// The object was changed concurrently: HTTP 409 or
+	// `version_conflict_engine_exception`.
+	ErrVersionConflict = errors.New("version conflict")
+	// The request can't be done in the current state of the object, e.g.
+	// deleting a started detector: `status_exception`.
+	ErrStatus = errors.New("status exception")
+)
+
+// OpenSearchError is an error response of OpenSearch, whatever the client the
+// request was made with, matching the class of its type or status code with
+// errors.Is.
+type OpenSearchError struct {
+	StatusCode int
+	// e.g. index_not_found_exception, empty if the response has no type
+	Type   string
+	Reason string
+	Err    error
+}
+
+func (e *OpenSearchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OpenSearchError) Unwrap() error {
+	return e.Err
+}
+
+func (e *OpenSearchError) Is(target error) bool {
+	if target == nil {
+		return false
+	}
+	return target == typeClass(e.Type) || target == statusClass(e.StatusCode)
+}
+
+// typeClass returns the class of an error response of type errorType, nil if
+// it has none.
+func typeClass(errorType string) error {
+	switch errorType {
+	case "resource_not_found_exception", "index_not_found_exception":
+		return ErrNotFound
+	case "security_exception":
+		return ErrSecurity
+	case "version_conflict_engine_exception":
+		return ErrVersionConflict
+	case "status_exception":
+		return ErrStatus
+	}
+	return nil
+}
+
+// statusClass returns the class of an error response with statusCode, nil if
+// it has none. An error response may be of two classes, e.g. plugins answer a
+// missing object with a `status_exception` and a 404.
+func statusClass(statusCode int) error {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrSecurity
+	case http.StatusConflict:
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// translateError returns err as an *OpenSearchError if it is, or wraps, an
+// error response of the elastic7, elastic6 or opensearch-go clients, and err
+// unchanged otherwise.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var osErr *OpenSearchError
+	if errors.As(err, &osErr) {
+		return err
+	}
+
+	var e7 *elastic7.Error
+	if errors.As(err, &e7) {
+		translated := &OpenSearchError{StatusCode: e7.Status, Err: err}
+		if e7.Details != nil {
+			translated.Type, translated.Reason = e7.Details.Type, e7.Details.Reason
+		}
+		return translated
+	}
+
+	var e6 *elastic6.Error
+	if errors.As(err, &e6) {
+		translated := &OpenSearchError{StatusCode: e6.Status, Err: err}
+		if e6.Details != nil {
+			translated.Type, translated.Reason = e6.Details.Type, e6.Details.Reason
+		}
+		return translated
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return &OpenSearchError{StatusCode: httpErr.StatusCode, Type: httpErr.Type, Reason: httpErr.Message, Err: err}
+	}
+
+	return err
+}
+
+// isNotFound reports whether err is an error response of OpenSearch for an
+// object that doesn't exist, in which case a Read removes the resource from
+// the state.
+func isNotFound(err error) bool {
+	return errors.Is(translateError(err), ErrNotFound)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	elastic7 "github.com/olivere/elastic/v7"
+	elastic6 "gopkg.in/olivere/elastic.v6"
+)
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want error
+		// another class of an error of two classes
+		also error
+	}{
+		{
+			name: "index not found",
+			err:  &elastic7.Error{Status: http.StatusNotFound, Details: &elastic7.ErrorDetails{Type: "index_not_found_exception", Reason: "no such index [logs]"}},
+			want: ErrNotFound,
+		},
+		{
+			name: "security plugin not found without a type",
+			err:  &elastic7.Error{Status: http.StatusNotFound},
+			want: ErrNotFound,
+		},
+		{
+			name: "wrapped resource not found",
+			err:  fmt.Errorf("error getting policy: %w", &elastic7.Error{Status: http.StatusNotFound, Details: &elastic7.ErrorDetails{Type: "resource_not_found_exception"}}),
+			want: ErrNotFound,
+		},
+		{
+			name: "security exception",
+			err:  &elastic7.Error{Status: http.StatusForbidden, Details: &elastic7.ErrorDetails{Type: "security_exception"}},
+			want: ErrSecurity,
+		},
+		{
+			name: "version conflict",
+			err:  &elastic7.Error{Status: http.StatusConflict, Details: &elastic7.ErrorDetails{Type: "version_conflict_engine_exception"}},
+			want: ErrVersionConflict,
+		},
+		{
+			name: "status exception with a bad request status",
+			err:  &elastic7.Error{Status: http.StatusBadRequest, Details: &elastic7.ErrorDetails{Type: "status_exception", Reason: "Detector job is running"}},
+			want: ErrStatus,
+		},
+		{
+			name: "elastic6",
+			err:  &elastic6.Error{Status: http.StatusNotFound, Details: &elastic6.ErrorDetails{Type: "resource_not_found_exception"}},
+			want: ErrNotFound,
+		},
+		{
+			name: "http error",
+			err:  &HTTPError{Operation: "read ML Model", StatusCode: http.StatusNotFound, Type: "status_exception"},
+			want: ErrNotFound,
+			also: ErrStatus,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := translateError(tc.err)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("expected %v to be %v", err, tc.want)
+			}
+			if tc.also != nil && !errors.Is(err, tc.also) {
+				t.Errorf("expected %v to be %v", err, tc.also)
+			}
+			for _, class := range []error{ErrNotFound, ErrSecurity, ErrVersionConflict, ErrStatus} {
+				if class != tc.want && class != tc.also && errors.Is(err, class) {
+					t.Errorf("expected %v not to be %v", err, class)
+				}
+			}
+			if err.Error() != tc.err.Error() {
+				t.Errorf("expected the message to be kept, got %q", err.Error())
+			}
+		})
+	}
+
+	if err := translateError(&elastic7.Error{Status: http.StatusBadRequest}); errors.Is(err, ErrNotFound) || errors.Is(err, ErrStatus) {
+		t.Errorf("expected a bad request not to be classified, got %v", err)
+	}
+	plain := errors.New("connection refused")
+	if translateError(plain) != plain || translateError(nil) != nil {
+		t.Errorf("expected errors other than error responses to be left unchanged")
+	}
+}
+
+func TestReadRemovesMissingResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version":{"number":"2.13.0"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		switch r.URL.Path {
+		case "/_ingest/pipeline/missing":
+			_, _ = w.Write([]byte(`{}`))
+		case "/_snapshot/missing":
+			_, _ = w.Write([]byte(`{"error":{"type":"repository_missing_exception","reason":"[missing] missing"},"status":404}`))
+		default:
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception","reason":"missing not found"},"status":404}`))
+		}
+	}))
+	defer server.Close()
+
+	parsedUrl, _ := url.Parse(server.URL)
+	conf := &ProviderConf{rawUrl: server.URL, parsedUrl: parsedUrl, pingTimeoutSeconds: 5}
+
+	resources := map[string]*schema.Resource{
+		"opensearch_ingest_pipeline":       resourceOpensearchIngestPipeline(),
+		"opensearch_snapshot_repository":   resourceOpensearchSnapshotRepository(),
+		"opensearch_ism_policy":            resourceOpenSearchISMPolicy(),
+		"opensearch_sm_policy":             resourceOpenSearchSMPolicy(),
+		"opensearch_channel_configuration": resourceOpenSearchChannelConfiguration(),
+	}
+	for name, r := range resources {
+		t.Run(name, func(t *testing.T) {
+			d := r.TestResourceData()
+			d.SetId("missing")
+			_ = d.Set("policy_name", "missing")
+
+			if err := r.Read(d, conf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.Id() != "" {
+				t.Errorf("expected the resource to be removed from the state")
+			}
+		})
+	}
+}
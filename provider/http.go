@@ -1,7 +1,15 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"math"
 	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
 )
 
 type withHeader struct {
@@ -28,3 +36,169 @@ func (h withHeader) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	return h.rt.RoundTrip(req)
 }
+
+// sharedTransport defers to the provider's shared HTTP client, building it on
+// the first request. This lets the opensearch-go client be created when the
+// provider is configured without resolving credentials up front.
+type sharedTransport struct {
+	conf *ProviderConf
+}
+
+func (t *sharedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	client, err := getHttpClient(t.conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Transport.RoundTrip(req)
+}
+
+// limitTransport caps the rate and the concurrency of requests sent to the
+// cluster. It wraps the provider's shared HTTP client, so the limits apply to
+// all resources together rather than to each one.
+type limitTransport struct {
+	rt       http.RoundTripper
+	limiter  *rate.Limiter
+	inflight chan struct{}
+}
+
+// WithLimits wraps rt so that at most requestsPerSecond requests are started
+// per second and at most maxConcurrent are in flight at any time. A zero
+// value disables the corresponding limit.
+func WithLimits(rt http.RoundTripper, requestsPerSecond float64, maxConcurrent int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrent <= 0 {
+		return rt
+	}
+
+	t := &limitTransport{rt: rt}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	}
+	if maxConcurrent > 0 {
+		t.inflight = make(chan struct{}, maxConcurrent)
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	release := func() {}
+	if t.inflight != nil {
+		select {
+		case t.inflight <- struct{}{}:
+			release = func() { <-t.inflight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	res, err := t.rt.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if t.inflight == nil {
+		return res, nil
+	}
+
+	// The request is in flight until its response has been read, so the
+	// slot is released when the body is closed.
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releaseOnClose calls release once, when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// activeURLTransport records the base URL of the node that answered the last
+// request, so the opensearch_host data source can report it.
+type activeURLTransport struct {
+	rt   http.RoundTripper
+	conf *ProviderConf
+}
+
+func (t *activeURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err == nil {
+		u := req.URL.Scheme + "://" + req.URL.Host
+		t.conf.activeUrl.Store(&u)
+	}
+
+	return res, err
+}
+
+// authorizationSource provides the Authorization header for credentials that
+// may change while the provider runs, e.g. expiring tokens or rotated
+// passwords.
+type authorizationSource interface {
+	// Authorization returns the current header value, refreshing the
+	// credentials if needed.
+	Authorization(ctx context.Context) (string, error)
+	// Invalidate drops the cached credentials if authorization is still the
+	// current header value, so that the next call refreshes them.
+	Invalidate(authorization string)
+}
+
+// authTransport sets the Authorization header of requests from an
+// authorizationSource. A request rejected with HTTP 401 is sent once more
+// with refreshed credentials, in case they were rotated or revoked.
+type authTransport struct {
+	rt     http.RoundTripper
+	source authorizationSource
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body has to be buffered so that it can be sent again with the
+	// refreshed credentials.
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		authorization, err := t.source.Authorization(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		attemptReq.Header.Set("Authorization", authorization)
+
+		res, err := t.rt.RoundTrip(attemptReq)
+		if err != nil || res.StatusCode != http.StatusUnauthorized || attempt > 1 {
+			return res, err
+		}
+
+		log.Printf("[DEBUG] Credentials rejected by %s, refreshing them", req.URL.Host)
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		t.source.Invalidate(authorization)
+	}
+}
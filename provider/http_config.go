@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// httpConfig tunes the connections to the cluster. The defaults, also those
+// of the provider `http` block, bound the time spent connecting to a node so
+// that an unresponsive one fails the request instead of stalling the apply.
+type httpConfig struct {
+	// zero for no timeout
+	requestTimeout      time.Duration
+	dialTimeout         time.Duration
+	tlsHandshakeTimeout time.Duration
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	forceHTTP2          bool
+}
+
+func defaultHTTPConfig() *httpConfig {
+	return &httpConfig{
+		dialTimeout:         30 * time.Second,
+		tlsHandshakeTimeout: 10 * time.Second,
+		maxIdleConnsPerHost: 10,
+		idleConnTimeout:     90 * time.Second,
+	}
+}
+
+func expandHTTPConfig(raw []interface{}) (*httpConfig, error) {
+	if len(raw) == 0 || raw[0] == nil {
+		return defaultHTTPConfig(), nil
+	}
+	m := raw[0].(map[string]interface{})
+
+	config := &httpConfig{
+		maxIdleConnsPerHost: m["max_idle_conns_per_host"].(int),
+		forceHTTP2:          m["force_http2"].(bool),
+	}
+	durations := map[string]*time.Duration{
+		"request_timeout":       &config.requestTimeout,
+		"dial_timeout":          &config.dialTimeout,
+		"tls_handshake_timeout": &config.tlsHandshakeTimeout,
+		"idle_conn_timeout":     &config.idleConnTimeout,
+	}
+	for key, d := range durations {
+		v := m[key].(string)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http %s: %w", key, err)
+		}
+		*d = parsed
+	}
+
+	return config, nil
+}
+
+// apply sets the connection settings of config on transport.
+func (config *httpConfig) apply(transport *http.Transport) {
+	dialer := &net.Dialer{
+		Timeout:   config.dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	transport.DialContext = dialer.DialContext
+	transport.TLSHandshakeTimeout = config.tlsHandshakeTimeout
+	transport.MaxIdleConnsPerHost = config.maxIdleConnsPerHost
+	transport.IdleConnTimeout = config.idleConnTimeout
+	// HTTP/2 is only attempted by default without a custom TLS
+	// configuration, which the cluster transport always has.
+	transport.ForceAttemptHTTP2 = config.forceHTTP2
+}
+
+// timeoutTransport bounds the time of each request, reading the response body
+// included. Unlike http.Client.Timeout, it also applies to the opensearch-go
+// client, which uses the transport directly.
+type timeoutTransport struct {
+	rt      http.RoundTripper
+	timeout time.Duration
+}
+
+// WithRequestTimeout wraps rt so that requests are cancelled after timeout. A
+// zero timeout returns rt unchanged.
+func WithRequestTimeout(rt http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	if timeout <= 0 {
+		return rt
+	}
+
+	return &timeoutTransport{rt: rt, timeout: timeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := t.rt.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelOnClose cancels the context of a request once its response body is
+// closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
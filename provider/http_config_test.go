@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestExpandHTTPConfig(t *testing.T) {
+	config, err := expandHTTPConfig(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *config != *defaultHTTPConfig() {
+		t.Errorf("expected the defaults without an http block, got %+v", config)
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"http": []interface{}{
+			map[string]interface{}{
+				"request_timeout":         "2m",
+				"dial_timeout":            "5s",
+				"max_idle_conns_per_host": 32,
+				"force_http2":             true,
+			},
+		},
+	})
+	config, err = expandHTTPConfig(d.Get("http").([]interface{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := httpConfig{
+		requestTimeout:      2 * time.Minute,
+		dialTimeout:         5 * time.Second,
+		tlsHandshakeTimeout: 10 * time.Second,
+		maxIdleConnsPerHost: 32,
+		idleConnTimeout:     90 * time.Second,
+		forceHTTP2:          true,
+	}
+	if *config != want {
+		t.Errorf("got %+v, want %+v", *config, want)
+	}
+}
+
+func TestClusterTransportHTTPConfig(t *testing.T) {
+	conf := &ProviderConf{http: &httpConfig{
+		tlsHandshakeTimeout: 3 * time.Second,
+		maxIdleConnsPerHost: 16,
+		idleConnTimeout:     time.Minute,
+		forceHTTP2:          true,
+	}}
+
+	transport, err := clusterTransport(conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.TLSHandshakeTimeout != 3*time.Second || transport.MaxIdleConnsPerHost != 16 || transport.IdleConnTimeout != time.Minute || !transport.ForceAttemptHTTP2 {
+		t.Errorf("expected the http settings to be applied, got %+v", transport)
+	}
+	if transport.DialContext == nil {
+		t.Errorf("expected a dialer with a timeout")
+	}
+
+	transport, err = clusterTransport(&ProviderConf{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if transport.TLSHandshakeTimeout != defaultHTTPConfig().tlsHandshakeTimeout {
+		t.Errorf("expected the default settings without an http block, got %+v", transport)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: WithRequestTimeout(http.DefaultTransport, 100*time.Millisecond)}
+
+	res, err := client.Get(server.URL + "/fast")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil || string(body) != `{"acknowledged":true}` {
+		t.Errorf("expected the body to be readable, got %q, %v", body, err)
+	}
+
+	_, err = client.Get(server.URL + "/slow")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to time out, got %v", err)
+	}
+
+	if rt := WithRequestTimeout(http.DefaultTransport, 0); rt != http.DefaultTransport {
+		t.Errorf("expected no timeout to leave the transport unchanged")
+	}
+}
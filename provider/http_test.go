@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithLimits_CapsConcurrentRequests(t *testing.T) {
+	var inflight, maxInflight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			m := maxInflight.Load()
+			if n <= m || maxInflight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: WithLimits(http.DefaultTransport, 0, 2)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInflight.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestWithLimits_ReleasesSlotWhenBodyIsClosed(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: WithLimits(http.DefaultTransport, 0, 1)}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		res, err := client.Get(server.URL)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		res.Body.Close()
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("expected the second request to wait until the body of the first one is closed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls: got %d, want 1", got)
+	}
+
+	res.Body.Close()
+	res.Body.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the second request to be sent once the body of the first one is closed")
+	}
+}
+
+func TestWithLimits_ReleasesSlotOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: WithLimits(http.DefaultTransport, 0, 1), Timeout: time.Second}
+	for i := 0; i < 3; i++ {
+		_, err := client.Get(url)
+		var netErr net.Error
+		if err == nil || (errors.As(err, &netErr) && netErr.Timeout()) {
+			t.Fatalf("request %d: expected the connection to be refused, got %v", i, err)
+		}
+	}
+}
+
+func TestWithLimits_LimitsRequestRate(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	// A rate of 10/s with a burst of 10 lets the first 10 requests through
+	// immediately; the next 5 need another half second of tokens.
+	client := &http.Client{Transport: WithLimits(http.DefaultTransport, 10, 0)}
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		res, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("expected requests to be rate limited, 15 requests took %s", elapsed)
+	}
+	if got := calls.Load(); got != 15 {
+		t.Errorf("calls: got %d, want 15", got)
+	}
+}
+
+func TestWithLimits_Unlimited(t *testing.T) {
+	rt := http.DefaultTransport
+	if got := WithLimits(rt, 0, 0); got != rt {
+		t.Errorf("expected the transport to be returned unwrapped without limits")
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tokens are refreshed this long before they expire, so that a token doesn't
+// expire while a request is in flight.
+const oidcTokenExpiryDelta = 30 * time.Second
+
+// oidcConfig holds the client credentials used to fetch access tokens from an
+// OpenID Connect provider.
+type oidcConfig struct {
+	tokenEndpoint string
+	clientID      string
+	clientSecret  string
+	scopes        []string
+	audience      string
+}
+
+func expandOIDCConfig(raw []interface{}) *oidcConfig {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	m := raw[0].(map[string]interface{})
+
+	return &oidcConfig{
+		tokenEndpoint: m["token_endpoint"].(string),
+		clientID:      m["client_id"].(string),
+		clientSecret:  m["client_secret"].(string),
+		scopes:        expandStringList(m["scopes"].([]interface{})),
+		audience:      m["audience"].(string),
+	}
+}
+
+// oidcTokenSource fetches access tokens with the client credentials grant and
+// caches them until shortly before they expire.
+type oidcTokenSource struct {
+	config *oidcConfig
+	client *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+// newOIDCTokenSource returns a token source fetching tokens through rt.
+func newOIDCTokenSource(config *oidcConfig, rt http.RoundTripper) *oidcTokenSource {
+	return &oidcTokenSource{
+		config: config,
+		client: &http.Client{Transport: rt, Timeout: 30 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// Authorization returns a bearer token header, fetching a new token if the
+// cached one is about to expire.
+func (s *oidcTokenSource) Authorization(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Before(s.expiry.Add(-oidcTokenExpiryDelta))) {
+		return "Bearer " + s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiry = time.Time{}
+	if expiresIn > 0 {
+		s.expiry = s.now().Add(time.Duration(expiresIn) * time.Second)
+	}
+
+	return "Bearer " + s.token, nil
+}
+
+// Invalidate drops the cached token if it is still the one of authorization.
+func (s *oidcTokenSource) Invalidate(authorization string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if "Bearer "+s.token == authorization {
+		s.token = ""
+	}
+}
+
+func (s *oidcTokenSource) fetch(ctx context.Context) (string, int64, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.config.scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.scopes, " "))
+	}
+	if s.config.audience != "" {
+		form.Set("audience", s.config.audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.config.clientID), url.QueryEscape(s.config.clientSecret))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("error fetching OIDC token: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("error reading OIDC token response: %w", err)
+	}
+
+	var parsed struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil && res.StatusCode == http.StatusOK {
+		return "", 0, fmt.Errorf("error parsing OIDC token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		if parsed.Error != "" {
+			return "", 0, fmt.Errorf("error fetching OIDC token: HTTP %d: %s %s", res.StatusCode, parsed.Error, parsed.ErrorDescription)
+		}
+		return "", 0, fmt.Errorf("error fetching OIDC token: HTTP %d", res.StatusCode)
+	}
+	if parsed.AccessToken == "" {
+		return "", 0, fmt.Errorf("OIDC token response from %s has no access_token", s.config.tokenEndpoint)
+	}
+
+	log.Printf("[DEBUG] Fetched OIDC token expiring in %ds", parsed.ExpiresIn)
+	return parsed.AccessToken, parsed.ExpiresIn, nil
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakeTokenServer issues numbered access tokens, valid for expiresIn seconds,
+// to the client credentials it expects.
+type fakeTokenServer struct {
+	server    *httptest.Server
+	issued    atomic.Int32
+	expiresIn int
+}
+
+func newFakeTokenServer(t *testing.T, expiresIn int) *fakeTokenServer {
+	t.Helper()
+	f := &fakeTokenServer{expiresIn: expiresIn}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "terraform" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad credentials"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse token request: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "client_credentials" {
+			t.Errorf("grant_type: got %q, want client_credentials", got)
+		}
+		if got := r.PostForm.Get("scope"); got != "openid opensearch" {
+			t.Errorf("scope: got %q, want %q", got, "openid opensearch")
+		}
+		if got := r.PostForm.Get("audience"); got != "opensearch" {
+			t.Errorf("audience: got %q, want opensearch", got)
+		}
+
+		n := f.issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, f.expiresIn)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeTokenServer) config() *oidcConfig {
+	return &oidcConfig{
+		tokenEndpoint: f.server.URL,
+		clientID:      "terraform",
+		clientSecret:  "s3cret",
+		scopes:        []string{"openid", "opensearch"},
+		audience:      "opensearch",
+	}
+}
+
+// newBearerServer records the bearer token of each request and rejects the
+// ones listed in revoked with HTTP 401.
+func newBearerServer(t *testing.T, revoked ...string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		tokens = append(tokens, token)
+		if containsString(revoked, token) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &tokens
+}
+
+func TestOIDCTokenSource_RefreshesExpiredTokens(t *testing.T) {
+	tokenServer := newFakeTokenServer(t, 300)
+	server, tokens := newBearerServer(t)
+
+	now := time.Now()
+	source := newOIDCTokenSource(tokenServer.config(), http.DefaultTransport)
+	source.now = func() time.Time { return now }
+	client := &http.Client{Transport: &authTransport{rt: http.DefaultTransport, source: source}}
+
+	get := func() {
+		t.Helper()
+		res, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	get()
+	now = now.Add(4 * time.Minute)
+	get()
+	// within oidcTokenExpiryDelta of the expiry
+	now = now.Add(45 * time.Second)
+	get()
+
+	want := []string{"token-1", "token-1", "token-2"}
+	if strings.Join(*tokens, ",") != strings.Join(want, ",") {
+		t.Errorf("tokens sent: got %v, want %v", *tokens, want)
+	}
+}
+
+func TestOIDCTokenSource_RetriesWithNewTokenOnUnauthorized(t *testing.T) {
+	tokenServer := newFakeTokenServer(t, 300)
+	server, tokens := newBearerServer(t, "token-1")
+
+	client := &http.Client{Transport: &authTransport{rt: http.DefaultTransport, source: newOIDCTokenSource(tokenServer.config(), http.DefaultTransport)}}
+	res, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	want := []string{"token-1", "token-2"}
+	if strings.Join(*tokens, ",") != strings.Join(want, ",") {
+		t.Errorf("tokens sent: got %v, want %v", *tokens, want)
+	}
+}
+
+func TestOIDCTokenSource_TokenEndpointError(t *testing.T) {
+	tokenServer := newFakeTokenServer(t, 300)
+	server, tokens := newBearerServer(t)
+
+	config := tokenServer.config()
+	config.clientSecret = "wrong"
+	client := &http.Client{Transport: &authTransport{rt: http.DefaultTransport, source: newOIDCTokenSource(config, http.DefaultTransport)}}
+
+	_, err := client.Get(server.URL)
+	if err == nil || !strings.Contains(err.Error(), "invalid_client bad credentials") {
+		t.Errorf("expected the token endpoint error to be returned, got %v", err)
+	}
+	if len(*tokens) != 0 {
+		t.Errorf("expected no request to be sent without a token, got %d", len(*tokens))
+	}
+}
+
+func TestOIDCTokenSource_ClusterTLSSettings(t *testing.T) {
+	tokenServer := newFakeTokenServer(t, 300)
+	tlsTokenServer := httptest.NewTLSServer(tokenServer.server.Config.Handler)
+	t.Cleanup(tlsTokenServer.Close)
+	server, tokens := newBearerServer(t)
+
+	config := tokenServer.config()
+	config.tokenEndpoint = tlsTokenServer.URL
+	conf := &ProviderConf{oidc: config}
+	client, err := createNonAWSHttpClient(conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Get(server.URL); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("expected the certificate of the token endpoint to be rejected without cacert_file, got %v", err)
+	}
+
+	conf = &ProviderConf{
+		oidc:         config,
+		cacertFile:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsTokenServer.Certificate().Raw})),
+		hostOverride: "opensearch.internal",
+	}
+	client, err = createNonAWSHttpClient(conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if len(*tokens) != 1 || (*tokens)[0] != "token-1" {
+		t.Errorf("expected the token to be fetched trusting cacert_file, got %v", *tokens)
+	}
+}
+
+func TestExpandOIDCConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"oidc": []interface{}{
+			map[string]interface{}{
+				"token_endpoint": "https://idp.example.com/oauth2/token",
+				"client_id":      "terraform",
+				"client_secret":  "s3cret",
+				"scopes":         []interface{}{"opensearch"},
+			},
+		},
+	})
+
+	conf := expandOIDCConfig(d.Get("oidc").([]interface{}))
+	if conf == nil || conf.tokenEndpoint != "https://idp.example.com/oauth2/token" || conf.clientID != "terraform" || conf.clientSecret != "s3cret" || len(conf.scopes) != 1 || conf.audience != "" {
+		t.Errorf("unexpected OIDC config: %+v", conf)
+	}
+
+	if conf := expandOIDCConfig(nil); conf != nil {
+		t.Errorf("expected no OIDC config when the block is absent, got %+v", conf)
+	}
+}
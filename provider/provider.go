@@ -12,18 +12,22 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
-	awsstscreds "github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-	awssession "github.com/aws/aws-sdk-go/aws/session"
-	awssigv4 "github.com/aws/aws-sdk-go/aws/signer/v4"
-	awssts "github.com/aws/aws-sdk-go/service/sts"
-	"github.com/deoxxa/aws_signing_client"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	awsstscreds "github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	awssts "github.com/aws/aws-sdk-go-v2/service/sts"
+	awslogging "github.com/aws/smithy-go/logging"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	elastic7 "github.com/olivere/elastic/v7"
 	"github.com/opensearch-project/opensearch-go/v2"
@@ -44,14 +48,18 @@ var minimalOpensearchServerlessVersion = "2.0.0"
 
 type ProviderConf struct {
 	rawUrl                   string
+	urls                     []string
 	insecure                 bool
 	sniffing                 bool
 	healthchecking           bool
 	cacertFile               string
 	username                 string
 	password                 string
+	passwordFile             string
+	credentialProcess        []string
 	token                    string
 	tokenName                string
+	oidc                     *oidcConfig
 	parsedUrl                *url.URL
 	signAWSRequests          bool
 	osVersion                string
@@ -60,6 +68,7 @@ type ProviderConf struct {
 	awsAssumeRoleArn         string
 	awsAssumeRoleExternalID  string
 	awsAssumeRoleSessionName string
+	awsAssumeRoleChain       []assumeRoleConfig
 	awsWebIdentityRoleArn    string
 	awsWebIdentityTokenFile  string
 	awsAccessKeyId           string
@@ -70,22 +79,61 @@ type ProviderConf struct {
 	certPemPath              string
 	keyPemPath               string
 	hostOverride             string
+	tlsMinVersion            string
 	proxy                    string
+	retry                    *retryConfig
+	maxRequestsPerSecond     float64
+	maxConcurrentRequests    int
+	http                     *httpConfig
+	headers                  map[string]string
+	requestLogging           *requestLoggingConfig
+	dryRun                   bool
+	dryRunManifest           string
+	// context of the provider configuration, carrying the logger used for
+	// the request log
+	logCtx context.Context
 	// determined after connecting to the server
 	flavor ServerFlavor
 
 	// official OpenSearch client
 	osClient *opensearch.Client
+
+	// The HTTP and elastic7 clients are built on first use and then shared by
+	// every resource for the lifetime of the provider, so credential
+	// resolution and version detection only happen once.
+	clientMu   sync.Mutex
+	httpClient *http.Client
+	esClient   *elastic7.Client
+
+	// base URL of the node that answered the last request, see
+	// activeURLTransport
+	activeUrl atomic.Pointer[string]
+
+	// plugins installed on the cluster, listed on first use, see
+	// installedPlugins
+	pluginsMu    sync.Mutex
+	plugins      *[]string
+	pluginsKnown bool
+
+	// configurations of the named clusters resources can choose with their
+	// `cluster` attribute
+	clusters map[string]*ProviderConf
 }
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OPENSEARCH_URL", nil),
-				Description: "OpenSearch URL",
+				Description: "OpenSearch URL. One of `url` or `urls` must be set.",
+			},
+			"urls": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "OpenSearch URLs of several nodes of the same cluster. Requests are spread across the nodes and fail over to the next one when a node is unreachable. Takes precedence over `url`. Defaults to the comma-separated `OPENSEARCH_URLS` environment variable when `url` isn't set either.",
 			},
 			"sniff": {
 				Type:        schema.TypeBool,
@@ -111,6 +159,19 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("OPENSEARCH_PASSWORD", nil),
 				Description: "Password to use to connect to OpenSearch using basic auth",
 			},
+			"password_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"password", "credential_process"},
+				Description:   "Path to a file containing the password to use to connect to OpenSearch using basic auth. The file is read again when the cluster rejects the password, so it can be rotated while Terraform runs.",
+			},
+			"credential_process": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"password", "password_file"},
+				Description:   "Command, and its arguments, printing the basic auth credentials to use as JSON on stdout: `{\"username\": \"...\", \"password\": \"...\", \"expiry\": \"2006-01-02T15:04:05Z\"}`. `username` defaults to the provider `username` and `expiry` is optional. The command is run again when the credentials expire or are rejected by the cluster.",
+			},
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -123,17 +184,107 @@ func Provider() *schema.Provider {
 				Default:     "ApiKey",
 				Description: "The type of token, usually ApiKey or Bearer",
 			},
+			"oidc": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"token"},
+				Description:   "Authenticate with bearer tokens fetched from an OpenID Connect provider using the client credentials grant. The token endpoint is reached with the TLS settings and proxy of the cluster. Tokens are refreshed shortly before they expire, and when rejected by the cluster. Not used when requests are signed for AWS.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_endpoint": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "URL of the token endpoint of the OpenID Connect provider.",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Client ID to authenticate with.",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Client secret to authenticate with.",
+						},
+						"scopes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Scopes to request.",
+						},
+						"audience": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Audience to request the token for, as required by some providers.",
+						},
+					},
+				},
+			},
 			"aws_assume_role_arn": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "Amazon Resource Name of an IAM Role to assume prior to making AWS API calls.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ConflictsWith: []string{"assume_role"},
+				Description:   "Amazon Resource Name of an IAM Role to assume prior to making AWS API calls.",
 			},
 			"aws_assume_role_external_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "External ID configured in the IAM policy of the IAM Role to assume prior to making AWS API calls.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Default:       "",
+				ConflictsWith: []string{"assume_role"},
+				Description:   "External ID configured in the IAM policy of the IAM Role to assume prior to making AWS API calls.",
+			},
+			"assume_role": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"aws_assume_role_arn", "aws_assume_role_external_id"},
+				Description:   "IAM Roles to assume in order prior to making AWS API calls, each one with the credentials of the previous one, for role chaining.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"arn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Amazon Resource Name of the IAM Role to assume.",
+						},
+						"external_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "External ID configured in the IAM policy of the IAM Role.",
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Session name of the assumed role. Defaults to `aws_assume_role_session_name`.",
+						},
+						"duration": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+							Description:  "Duration of the role session, e.g. `1h`. Defaults to 15 minutes.",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Session tags, e.g. for attribute-based access control.",
+						},
+						"transitive_tag_keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Keys of the session tags passed on to the roles assumed next in the chain.",
+						},
+						"policy": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "IAM policy in JSON further restricting the permissions of the role session.",
+						},
+					},
+				},
 			},
 			"aws_assume_role_session_name": {
 				Type:        schema.TypeString,
@@ -242,11 +393,160 @@ func Provider() *schema.Provider {
 				Default:     "",
 				Description: "If provided, sets the 'Host' header of requests and the 'ServerName' for certificate validation to this value. See the documentation on connecting to OpenSearch via an SSH tunnel.",
 			},
+			"tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+				Description:  "Minimum TLS version accepted when connecting to OpenSearch, one of `1.0`, `1.1`, `1.2` or `1.3`. Defaults to TLS 1.2.",
+			},
 			"proxy": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Proxy URL to use for requests to OpenSearch.",
 			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Sensitive:   true,
+				Description: "Custom HTTP headers sent with every request to OpenSearch, e.g. for an API gateway in front of the cluster. Defaults to the `OPENSEARCH_HEADERS` environment variable, given as comma-separated `Name=value` pairs.",
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests per second sent to OpenSearch, shared by all resources. Defaults to 0, which means unlimited.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests in flight to OpenSearch at any time, shared by all resources. Defaults to 0, which means unlimited.",
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry requests failing with a transient cluster error (e.g. HTTP 429, 502, 503, 504, `cluster_block_exception` or `process_cluster_event_timeout_exception`) with exponential backoff. A `Retry-After` header sent by the cluster is honored. Requests changing the cluster with POST or PATCH, e.g. `_bulk`, are only retried on HTTP 429 and 503, as they may have been applied otherwise.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Maximum number of attempts per request, including the first one.",
+						},
+						"base_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "500ms",
+							ValidateFunc: validateDuration,
+							Description:  "Backoff before the first retry, doubled on each subsequent attempt.",
+						},
+						"max_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: validateDuration,
+							Description:  "Upper bound on the backoff between attempts, including one requested via `Retry-After`.",
+						},
+						"retryable_status_codes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "HTTP status codes to retry. Defaults to 429, 502, 503 and 504.",
+						},
+					},
+				},
+			},
+			"http": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tuning of the HTTP connections to OpenSearch, applied to every node and authentication method.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"request_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDuration,
+							Description:  "Maximum duration of each request attempt, reading the response included, e.g. `2m`. Defaults to no timeout.",
+						},
+						"dial_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: validateDuration,
+							Description:  "Maximum duration to establish a TCP connection to a node.",
+						},
+						"tls_handshake_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "10s",
+							ValidateFunc: validateDuration,
+							Description:  "Maximum duration of the TLS handshake with a node.",
+						},
+						"max_idle_conns_per_host": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Maximum number of idle keep-alive connections kept open to each node.",
+						},
+						"idle_conn_timeout": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "90s",
+							ValidateFunc: validateDuration,
+							Description:  "Duration after which an idle keep-alive connection is closed.",
+						},
+						"force_http2": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Attempt HTTP/2 when connecting to the cluster over TLS. HTTP/1.1 is used otherwise.",
+						},
+					},
+				},
+			},
+			"request_logging": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Every request to OpenSearch is logged at DEBUG level with its method, path, status and latency, with `Authorization` and similar headers redacted. This block additionally logs request and response bodies.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"include_body": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Log JSON request and response bodies. Values of keys containing `password`, `credential`, `authorization` or `secret`, and of `hash` keys, are redacted.",
+						},
+						"redact_paths": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Additional dotted JSON paths to redact from logged bodies, e.g. `parameters.api_key`. Array elements share the path of the array.",
+						},
+					},
+				},
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OPENSEARCH_DRY_RUN", false),
+				Description: "Don't send the requests that would change the cluster. They are recorded in `dry_run_manifest` and answered with a synthetic success, while read requests are still sent to the cluster.",
+			},
+			"dry_run_manifest": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("OPENSEARCH_DRY_RUN_MANIFEST", "opensearch-dry-run.jsonl"),
+				Description: "File the requests not sent in dry run mode are appended to, one JSON object per line with the run, the sequence number within the run, and the method, host, path, query and body of the request. Secrets in bodies are redacted as in the request log.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -277,30 +577,104 @@ func Provider() *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"opensearch_host": dataSourceOpensearchHost(),
+			"opensearch_action_group":              dataSourceOpensearchActionGroup(),
+			"opensearch_action_groups":             dataSourceOpensearchActionGroups(),
+			"opensearch_cluster_health":            dataSourceOpensearchClusterHealth(),
+			"opensearch_cluster_info":              dataSourceOpensearchClusterInfo(),
+			"opensearch_component_template":        dataSourceOpensearchComponentTemplate(),
+			"opensearch_composable_index_template": dataSourceOpensearchComposableIndexTemplate(),
+			"opensearch_dashboard_tenant":          dataSourceOpensearchDashboardTenant(),
+			"opensearch_dashboard_tenants":         dataSourceOpensearchDashboardTenants(),
+			"opensearch_host":                      dataSourceOpensearchHost(),
+			"opensearch_index_template":            dataSourceOpensearchIndexTemplate(),
+			"opensearch_indices":                   dataSourceOpensearchIndices(),
+			"opensearch_role":                      dataSourceOpensearchRole(),
+			"opensearch_roles":                     dataSourceOpensearchRoles(),
+			"opensearch_roles_mapping":             dataSourceOpensearchRolesMapping(),
+			"opensearch_roles_mappings":            dataSourceOpensearchRolesMappings(),
+			"opensearch_simulate_index":            dataSourceOpensearchSimulateIndex(),
+			"opensearch_user":                      dataSourceOpensearchUser(),
+			"opensearch_users":                     dataSourceOpensearchUsers(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	provider.Schema["clusters"] = clustersSchema(provider.Schema)
+	for _, r := range provider.ResourcesMap {
+		withCluster(withDryRun(r), false)
+	}
+	for _, r := range provider.DataSourcesMap {
+		withCluster(r, true)
+	}
+
+	return provider
 }
 
 func providerConfigure(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	rawUrl := d.Get("url").(string)
+	headers, err := providerHeaders(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	clusters, diags := expandClusters(c, d, headers)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	urls := providerURLs(d)
+	if len(urls) == 0 {
+		if len(clusters) == 0 {
+			return nil, diag.Errorf("one of `url` or `urls` must be set")
+		}
+		// Without a default cluster, every resource has to set `cluster`.
+		return &ProviderConf{clusters: clusters}, diags
+	}
+
+	conf, confDiags := newProviderConf(c, d, urls, headers)
+	diags = append(diags, confDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	conf.clusters = clusters
+
+	return conf, diags
+}
+
+// providerData is the configuration of the provider or of one of its
+// clusters.
+type providerData interface {
+	Get(key string) interface{}
+}
+
+// newProviderConf returns the configuration to connect to the cluster of the
+// nodes at urls.
+func newProviderConf(c context.Context, d providerData, urls []string, headers map[string]string) (*ProviderConf, diag.Diagnostics) {
+	rawUrl := urls[0]
 	parsedUrl, err := url.Parse(rawUrl)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	for _, u := range urls[1:] {
+		if _, err := url.Parse(u); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
 
 	conf := &ProviderConf{
 		rawUrl:             rawUrl,
+		urls:               urls,
 		insecure:           d.Get("insecure").(bool),
 		sniffing:           d.Get("sniff").(bool),
 		healthchecking:     d.Get("healthcheck").(bool),
 		cacertFile:         d.Get("cacert_file").(string),
 		username:           d.Get("username").(string),
 		password:           d.Get("password").(string),
+		passwordFile:       d.Get("password_file").(string),
+		credentialProcess:  expandStringList(d.Get("credential_process").([]interface{})),
 		token:              d.Get("token").(string),
 		tokenName:          d.Get("token_name").(string),
+		oidc:               expandOIDCConfig(d.Get("oidc").([]interface{})),
 		parsedUrl:          parsedUrl,
 		signAWSRequests:    d.Get("sign_aws_requests").(bool),
 		awsSig4Service:     d.Get("aws_signature_service").(string),
@@ -320,20 +694,129 @@ func providerConfigure(c context.Context, d *schema.ResourceData) (interface{},
 		certPemPath:              d.Get("client_cert_path").(string),
 		keyPemPath:               d.Get("client_key_path").(string),
 		hostOverride:             d.Get("host_override").(string),
+		tlsMinVersion:            d.Get("tls_min_version").(string),
 		proxy:                    d.Get("proxy").(string),
+		maxRequestsPerSecond:     d.Get("max_requests_per_second").(float64),
+		maxConcurrentRequests:    d.Get("max_concurrent_requests").(int),
+	}
+
+	// opensearch_version is the version of an OpenSearch cluster, as opposed
+	// to an Elasticsearch one detected when pinging it.
+	if conf.osVersion != "" {
+		conf.flavor = OpenSearch
 	}
 
-	osClient, err := getOSClient(conf)
+	conf.headers = headers
+
+	conf.retry, err = expandRetryConfig(d.Get("retry").([]interface{}))
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
-	conf.osClient = osClient
+
+	conf.awsAssumeRoleChain, err = expandAssumeRoleConfigs(d.Get("assume_role").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	conf.http, err = expandHTTPConfig(d.Get("http").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	conf.requestLogging = expandRequestLoggingConfig(d.Get("request_logging").([]interface{}))
+	conf.dryRun = d.Get("dry_run").(bool)
+	conf.dryRunManifest = d.Get("dry_run_manifest").(string)
+	secrets := []string{conf.password, conf.token, conf.awsSecretAccessKey, conf.awsSessionToken}
+	if conf.oidc != nil {
+		secrets = append(secrets, conf.oidc.clientSecret)
+	}
+	conf.logCtx = tflog.MaskAllFieldValuesStrings(c, nonEmptyStrings(secrets...)...)
 
 	resolveAWSWebIdentityEnv(conf)
 
+	// Unless AWS credentials have to be resolved, building the HTTP client
+	// makes no request, so do it now to report invalid TLS or proxy settings
+	// as configuration errors.
+	if region, _ := awsSigningRegion(conf); region == "" {
+		if _, err := getHttpClient(conf); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	} else if _, err := tlsConfig(conf); err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	osClient, err := newOSClient(conf)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	conf.osClient = osClient
+
 	return conf, awsCredentialWarnings(conf)
 }
 
+// providerURLs returns the URLs of the cluster nodes, from `urls`, `url` or
+// the OPENSEARCH_URLS environment variable, in that order.
+func providerURLs(d *schema.ResourceData) []string {
+	if urls := expandStringList(d.Get("urls").([]interface{})); len(urls) > 0 {
+		return urls
+	}
+
+	if u := d.Get("url").(string); u != "" {
+		return []string{u}
+	}
+
+	var urls []string
+	for _, u := range strings.Split(os.Getenv("OPENSEARCH_URLS"), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// providerHeaders returns the custom headers sent with every request, from
+// `headers` or the OPENSEARCH_HEADERS environment variable.
+func providerHeaders(d *schema.ResourceData) (map[string]string, error) {
+	headers := make(map[string]string)
+	if raw := d.Get("headers").(map[string]interface{}); len(raw) > 0 {
+		for k, v := range raw {
+			headers[k] = v.(string)
+		}
+		return headers, nil
+	}
+
+	env := os.Getenv("OPENSEARCH_HEADERS")
+	for _, pair := range strings.Split(env, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid header %q in OPENSEARCH_HEADERS, expected Name=value", pair)
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+
+	return headers, nil
+}
+
+// clusterURLs returns the URLs of all configured cluster nodes.
+func (conf *ProviderConf) clusterURLs() []string {
+	if len(conf.urls) > 0 {
+		return conf.urls
+	}
+	return []string{conf.rawUrl}
+}
+
+// activeURL returns the base URL of the node that answered the last request,
+// or the first configured URL if no request has been made yet.
+func (conf *ProviderConf) activeURL() string {
+	if u := conf.activeUrl.Load(); u != nil {
+		return *u
+	}
+	return conf.clusterURLs()[0]
+}
+
 // resolveAWSWebIdentityEnv applies the standard web identity environment
 // variables (AWS_ROLE_ARN, AWS_WEB_IDENTITY_TOKEN_FILE) to the provider
 // configuration so that EKS IRSA works with no explicit configuration.
@@ -354,7 +837,7 @@ func providerConfigure(c context.Context, d *schema.ResourceData) (interface{},
 func resolveAWSWebIdentityEnv(conf *ProviderConf) {
 	// An explicit profile disables env-var-sourced web identity (botocore's
 	// disable_env_vars). aws_access_key is not checked here because static keys
-	// already take unconditional precedence in awsSession.
+	// already take unconditional precedence in awsConfig.
 	if conf.awsProfile != "" {
 		return
 	}
@@ -391,9 +874,21 @@ func awsCredentialWarnings(conf *ProviderConf) diag.Diagnostics {
 	return diags
 }
 
-func getOSClient(conf *ProviderConf) (*opensearch.Client, error) {
+// newOSClient creates the opensearch-go client. Its transport defers to the
+// shared HTTP client, which is only built once the first request is made.
+func newOSClient(conf *ProviderConf) (*opensearch.Client, error) {
+	urls := conf.clusterURLs()
 	cfg := opensearch.Config{
-		Addresses: []string{conf.rawUrl},
+		Addresses: urls,
+		Transport: &sharedTransport{conf: conf},
+	}
+	if len(urls) > 1 {
+		// The client retries on the next node when one is unreachable.
+		cfg.MaxRetries = len(urls) - 1
+	} else {
+		// Retries are handled by the shared transport when configured, so
+		// the client's own retry on 502/503/504 doesn't multiply attempts.
+		cfg.DisableRetry = conf.retry != nil
 	}
 
 	if conf.username != "" && conf.password != "" {
@@ -406,72 +901,160 @@ func getOSClient(conf *ProviderConf) (*opensearch.Client, error) {
 		}
 	}
 
-	httpClient, err := createOSHttpClient(conf)
+	return opensearch.NewClient(cfg)
+}
+
+// getHttpClient returns the HTTP client shared by the elastic7 and
+// opensearch-go clients, building it on first use. A failed build is not
+// cached, so a later call may succeed once the underlying problem is fixed.
+func getHttpClient(conf *ProviderConf) (*http.Client, error) {
+	conf.clientMu.Lock()
+	defer conf.clientMu.Unlock()
+
+	return conf.sharedHttpClient()
+}
+
+// sharedHttpClient must be called with clientMu held.
+func (conf *ProviderConf) sharedHttpClient() (*http.Client, error) {
+	if conf.httpClient != nil {
+		return conf.httpClient, nil
+	}
+
+	client, err := createOSHttpClient(conf)
 	if err != nil {
 		return nil, err
 	}
+	if conf.http != nil {
+		client.Transport = WithRequestTimeout(client.Transport, conf.http.requestTimeout)
+	}
+	if conf.dryRun {
+		client.Transport = newDryRunTransport(client.Transport, conf.dryRunManifest, conf.requestLogging.redactPaths)
+	}
+	client.Transport = &requestLogTransport{rt: client.Transport, config: conf.requestLogging, ctx: conf.logCtx}
+	// Limits are applied inside the retry transport so that every attempt
+	// counts against them.
+	client.Transport = WithLimits(client.Transport, conf.maxRequestsPerSecond, conf.maxConcurrentRequests)
+	if conf.retry != nil {
+		client.Transport = &retryTransport{rt: client.Transport, config: conf.retry}
+	}
+	client.Transport = &activeURLTransport{rt: client.Transport, conf: conf}
+	conf.httpClient = client
 
-	cfg.Transport = httpClient.Transport
-
-	return opensearch.NewClient(cfg)
+	return client, nil
 }
 
-func createOSHttpClient(conf *ProviderConf) (*http.Client, error) {
+// awsSigningRegion returns the region requests should be signed for, or an
+// empty string if requests should not be signed. serverless is true when the
+// target is an Amazon OpenSearch Serverless collection.
+func awsSigningRegion(conf *ProviderConf) (region string, serverless bool) {
 	if !conf.signAWSRequests {
-		return createNonAWSHttpClient(conf), nil
+		return "", false
 	}
 
 	if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil {
-		return awsHttpClient(m[1], conf, map[string]string{})
+		return m[1], false
 	}
 
 	if m := awsOpensearchServerlessUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil {
-		client, err := awsHttpClient(m[1], conf, map[string]string{})
-		if err != nil {
-			return nil, err
-		}
-		client.Transport = Wrap(client.Transport)
-		return client, nil
+		return m[1], true
 	}
 
 	if conf.awsSig4Service == "aoss" && conf.awsRegion != "" {
-		client, err := awsHttpClient(conf.awsRegion, conf, map[string]string{})
-		if err != nil {
-			return nil, err
-		}
-		client.Transport = Wrap(client.Transport)
-		return client, nil
+		return conf.awsRegion, true
+	}
+
+	return conf.awsRegion, false
+}
+
+func createOSHttpClient(conf *ProviderConf) (*http.Client, error) {
+	region, serverless := awsSigningRegion(conf)
+	if region == "" {
+		return createNonAWSHttpClient(conf)
 	}
 
-	if conf.awsRegion != "" {
-		return awsHttpClient(conf.awsRegion, conf, map[string]string{})
+	log.Printf("[INFO] Using AWS: %+v", region)
+	if serverless {
+		conf.awsSig4Service = "aoss"
 	}
 
-	return createNonAWSHttpClient(conf), nil
+	client, err := awsHttpClient(region, conf, conf.headers)
+	if err != nil {
+		return nil, err
+	}
+	if serverless {
+		client.Transport = Wrap(client.Transport)
+	}
+
+	return client, nil
 }
 
-func createNonAWSHttpClient(conf *ProviderConf) *http.Client {
-	if conf.insecure || conf.cacertFile != "" {
-		client := tlsHttpClient(conf, map[string]string{})
-		if conf.token != "" {
-			return tokenHttpClient(conf, map[string]string{})
-		}
-		return client
+func createNonAWSHttpClient(conf *ProviderConf) (*http.Client, error) {
+	transport, err := clusterTransport(conf)
+	if err != nil {
+		return nil, err
 	}
 
+	// Wrapper to inject headers as needed
+	rt := WithHeader(transport)
+	rt.hostOverride = conf.hostOverride
 	if conf.token != "" {
-		return tokenHttpClient(conf, map[string]string{})
+		rt.Set("Authorization", fmt.Sprintf("%s %s", conf.tokenName, conf.token))
+	}
+	for k, v := range conf.headers {
+		rt.Set(k, v)
+	}
+
+	if conf.oidc != nil {
+		// Tokens are fetched with the TLS settings and proxy of the cluster,
+		// but host_override only applies to the cluster.
+		tokenTransport := transport.Clone()
+		tokenTransport.TLSClientConfig.ServerName = ""
+		return &http.Client{Transport: &authTransport{rt: rt, source: newOIDCTokenSource(conf.oidc, tokenTransport)}}, nil
+	}
+	if source := newBasicAuthSource(conf); source != nil {
+		return &http.Client{Transport: &authTransport{rt: rt, source: source}}, nil
 	}
 
-	return defaultHttpClient(conf, map[string]string{})
+	return &http.Client{Transport: rt}, nil
 }
 
+// getClient returns the elastic7 client shared by all resources, building it
+// (and detecting the server version) on first use.
 func getClient(conf *ProviderConf) (*elastic7.Client, error) {
+	conf.clientMu.Lock()
+	defer conf.clientMu.Unlock()
+
+	if conf.esClient != nil {
+		return conf.esClient, nil
+	}
+
+	httpClient, err := conf.sharedHttpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newElastic7Client(conf, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	conf.esClient = client
+
+	return client, nil
+}
+
+func newElastic7Client(conf *ProviderConf, httpClient *http.Client) (*elastic7.Client, error) {
+	urls := conf.clusterURLs()
 	opts := []elastic7.ClientOptionFunc{
-		elastic7.SetURL(conf.rawUrl),
+		elastic7.SetURL(urls...),
 		elastic7.SetScheme(conf.parsedUrl.Scheme),
 		elastic7.SetSniff(conf.sniffing),
 		elastic7.SetHealthcheck(conf.healthchecking),
+		elastic7.SetHttpClient(httpClient),
+	}
+	if len(urls) > 1 {
+		// Retry immediately on the next node when one is unreachable. Only
+		// transport errors are retried by the client.
+		opts = append(opts, elastic7.SetRetrier(elastic7.NewBackoffRetrier(elastic7.NewSimpleBackoff(make([]int, len(urls))...))))
 	}
 
 	if conf.parsedUrl.User.Username() != "" {
@@ -482,48 +1065,17 @@ func getClient(conf *ProviderConf) (*elastic7.Client, error) {
 		opts = append(opts, elastic7.SetBasicAuth(conf.username, conf.password))
 	}
 
-	if m := awsUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); m != nil && conf.signAWSRequests {
-		log.Printf("[INFO] Using AWS: %+v", m[1])
-		client, err := awsHttpClient(m[1], conf, map[string]string{})
-		if err != nil {
-			return nil, err
-		}
-		opts = append(opts, elastic7.SetHttpClient(client), elastic7.SetSniff(false))
-	} else if m := awsOpensearchServerlessUrlRegexp.FindStringSubmatch(conf.parsedUrl.Hostname()); (m != nil || (conf.awsSig4Service == "aoss" && conf.awsRegion != "")) && conf.signAWSRequests {
-		var region string
-		if m != nil {
-			region = m[1]
-		} else {
-			region = conf.awsRegion
-		}
-		log.Printf("[INFO] Using AWS: %+v", region)
-		conf.awsSig4Service = "aoss"
-		client, err := awsHttpClient(region, conf, map[string]string{})
-		if err != nil {
-			return nil, err
-		}
-		client.Transport = Wrap(client.Transport)
-		opts = append(opts, elastic7.SetHttpClient(client), elastic7.SetSniff(false))
+	region, serverless := awsSigningRegion(conf)
+	// Sniffing only works with the default client, the nodes' publish
+	// addresses won't match signed, TLS or token authenticated endpoints.
+	if region != "" || conf.insecure || conf.cacertFile != "" || conf.token != "" {
+		opts = append(opts, elastic7.SetSniff(false))
+	}
+	if serverless {
 		conf.flavor = OpenSearch
 		if conf.osVersion == "" {
 			conf.osVersion = minimalOpensearchServerlessVersion
 		}
-	} else if awsRegion := conf.awsRegion; conf.awsRegion != "" && conf.signAWSRequests {
-		log.Printf("[INFO] Using AWS: %+v", awsRegion)
-		client, err := awsHttpClient(awsRegion, conf, map[string]string{})
-		if err != nil {
-			return nil, err
-		}
-		opts = append(opts, elastic7.SetHttpClient(client), elastic7.SetSniff(false))
-	} else if conf.insecure || conf.cacertFile != "" {
-		opts = append(opts, elastic7.SetHttpClient(tlsHttpClient(conf, map[string]string{})), elastic7.SetSniff(false))
-		if conf.token != "" {
-			opts = append(opts, elastic7.SetHttpClient(tokenHttpClient(conf, map[string]string{})), elastic7.SetSniff(false))
-		}
-	} else if conf.token != "" {
-		opts = append(opts, elastic7.SetHttpClient(tokenHttpClient(conf, map[string]string{})), elastic7.SetSniff(false))
-	} else {
-		opts = append(opts, elastic7.SetHttpClient(defaultHttpClient(conf, map[string]string{})))
 	}
 
 	logProviderLevel, ok := os.LookupEnv("TF_LOG_PROVIDER")
@@ -567,23 +1119,8 @@ func getClient(conf *ProviderConf) (*elastic7.Client, error) {
 	}
 
 	if conf.osVersion == "" {
-		log.Printf("[INFO] Pinging url to determine version %+v with timeout %ds", conf.rawUrl, conf.pingTimeoutSeconds)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.pingTimeoutSeconds)*time.Second)
-		defer cancel()
-		info, httpStatus, err := client.Ping(conf.rawUrl).Do(ctx)
-		switch httpStatus {
-		case http.StatusForbidden:
-			return nil, errors.New("HTTP 403 Forbidden: Permission denied. Please ensure that the correct credentials are being used to access the cluster")
-		case http.StatusUnauthorized:
-			return nil, errors.New("HTTP 401 Unauthorized: Please ensure that the correct credentials are being used to access the cluster")
-		}
-
+		info, err := pingVersion(conf, client, urls)
 		if err != nil {
-			// Replace the timeout error because it gives no context
-			if os.IsTimeout(err) {
-				err = fmt.Errorf("timeout after %d seconds while pinging '%+v' to determine server version, please consider setting 'opensearch_version' to avoid this lookup", conf.pingTimeoutSeconds, conf.rawUrl)
-			}
-
 			return nil, err
 		}
 		conf.osVersion = info.Version.Number
@@ -600,73 +1137,111 @@ func getClient(conf *ProviderConf) (*elastic7.Client, error) {
 	return client, nil
 }
 
-func assumeRoleCredentials(region, roleARN, roleExternalID, roleSessionName, profile string, endpoint string, base *awscredentials.Credentials) *awscredentials.Credentials {
-	sessOpts := awsSessionOptions(region, endpoint)
-	if profile != "" {
-		sessOpts.Profile = profile
-	}
-	// When base credentials are supplied (e.g. resolved via web identity), sign
-	// the STS AssumeRole call with them so the role is chained from that
-	// identity rather than the ambient credential chain.
-	if base != nil {
-		sessOpts.Config.Credentials = base
+// pingVersion determines the server version from the first node that answers.
+func pingVersion(conf *ProviderConf, client *elastic7.Client, urls []string) (*elastic7.PingResult, error) {
+	var err error
+	for _, u := range urls {
+		var info *elastic7.PingResult
+		info, err = pingNodeVersion(conf, client, u)
+		if err == nil {
+			return info, nil
+		}
+		var authErr *pingAuthError
+		if errors.As(err, &authErr) {
+			return nil, err
+		}
+		log.Printf("[WARN] Pinging %+v failed: %s", u, err)
 	}
 
-	sess := awssession.Must(awssession.NewSessionWithOptions(sessOpts))
-	stsClient := awssts.New(sess)
-	assumeRoleProvider := &awsstscreds.AssumeRoleProvider{
-		Client:  stsClient,
-		RoleARN: roleARN,
-	}
+	return nil, err
+}
+
+type pingAuthError struct {
+	msg string
+}
+
+func (e *pingAuthError) Error() string {
+	return e.msg
+}
 
-	if roleExternalID != "" {
-		assumeRoleProvider.ExternalID = aws.String(roleExternalID)
+func pingNodeVersion(conf *ProviderConf, client *elastic7.Client, u string) (*elastic7.PingResult, error) {
+	log.Printf("[INFO] Pinging url to determine version %+v with timeout %ds", u, conf.pingTimeoutSeconds)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.pingTimeoutSeconds)*time.Second)
+	defer cancel()
+	info, httpStatus, err := client.Ping(u).Do(ctx)
+	switch httpStatus {
+	case http.StatusForbidden:
+		return nil, &pingAuthError{"HTTP 403 Forbidden: Permission denied. Please ensure that the correct credentials are being used to access the cluster"}
+	case http.StatusUnauthorized:
+		return nil, &pingAuthError{"HTTP 401 Unauthorized: Please ensure that the correct credentials are being used to access the cluster"}
 	}
 
-	if roleSessionName != "" {
-		assumeRoleProvider.RoleSessionName = roleSessionName
+	if err != nil {
+		// Replace the timeout error because it gives no context
+		if os.IsTimeout(err) {
+			err = fmt.Errorf("timeout after %d seconds while pinging '%+v' to determine server version, please consider setting 'opensearch_version' to avoid this lookup", conf.pingTimeoutSeconds, u)
+		}
+
+		return nil, err
 	}
 
-	return awscredentials.NewChainCredentials([]awscredentials.Provider{assumeRoleProvider})
+	return info, nil
 }
 
-func assumeRoleWithWebIdentityCredentials(region, roleARN, sessionName, tokenFile, endpoint string) *awscredentials.Credentials {
-	sessOpts := awsSessionOptions(region, endpoint)
-	sess := awssession.Must(awssession.NewSessionWithOptions(sessOpts))
-	stsClient := awssts.New(sess)
-	provider := awsstscreds.NewWebIdentityRoleProviderWithOptions(stsClient, roleARN, sessionName, awsstscreds.FetchTokenPath(tokenFile))
+func assumeRoleWithWebIdentityCredentials(cfg aws.Config, roleARN, sessionName, tokenFile string) aws.CredentialsProvider {
+	provider := awsstscreds.NewWebIdentityRoleProvider(awssts.NewFromConfig(cfg), roleARN, awsstscreds.IdentityTokenFile(tokenFile), func(o *awsstscreds.WebIdentityRoleOptions) {
+		o.RoleSessionName = sessionName
+	})
 
-	return awscredentials.NewChainCredentials([]awscredentials.Provider{provider})
+	return aws.NewCredentialsCache(provider)
 }
 
-func awsSessionOptions(region string, endpoint string) awssession.Options {
-	return awssession.Options{
-		Config: aws.Config{
-			Region:   aws.String(region),
-			LogLevel: aws.LogLevel(aws.LogDebugWithHTTPBody),
-			Logger: aws.LoggerFunc(func(args ...interface{}) {
-				log.Print(append([]interface{}{"[DEBUG] "}, args...))
-			}),
-			CredentialsChainVerboseErrors: aws.Bool(true),
-			MaxRetries:                    aws.Int(1),
-			// HTTP client is required to fetch EC2 metadata values
-			// having zero timeout on the default HTTP client sometimes makes
-			// it fail with Credential error
-			// https://github.com/aws/aws-sdk-go/issues/2914
-			HTTPClient: &http.Client{Timeout: 10 * time.Second},
-			Endpoint:   aws.String(endpoint),
-		},
-		SharedConfigState: awssession.SharedConfigEnable,
+func awsConfigOptions(region string, endpoint string, insecure bool) []func(*awsconfig.LoadOptions) error {
+	// HTTP client is required to fetch EC2 metadata values
+	// having zero timeout on the default HTTP client sometimes makes
+	// it fail with Credential error
+	// https://github.com/aws/aws-sdk-go/issues/2914
+	client := awshttp.NewBuildableClient().WithTimeout(10 * time.Second).WithTransportOptions(func(transport *http.Transport) {
+		// If configured as insecure, turn off SSL verification
+		if insecure {
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{}
+			}
+			transport.TLSClientConfig.InsecureSkipVerify = true
+		}
+	})
+
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(region),
+		awsconfig.WithClientLogMode(aws.LogRetries | aws.LogRequest | aws.LogResponse),
+		awsconfig.WithLogger(awslogging.LoggerFunc(func(classification awslogging.Classification, format string, v ...interface{}) {
+			log.Printf("[DEBUG] "+format, v...)
+		})),
+		awsconfig.WithRetryMaxAttempts(2),
+		awsconfig.WithHTTPClient(client),
+	}
+	if endpoint != "" {
+		opts = append(opts, awsconfig.WithBaseEndpoint(endpoint))
 	}
+
+	return opts
 }
 
-func awsSession(region string, conf *ProviderConf, endpoint string) *awssession.Session {
-	sessOpts := awsSessionOptions(region, endpoint)
+// awsConfig loads the AWS configuration whose credentials sign requests. The
+// shared config and credentials files are always loaded, so profiles can use
+// IAM Identity Center (SSO), credential_process or assume a role, and the
+// default chain includes container credential endpoints.
+func awsConfig(ctx context.Context, region string, conf *ProviderConf, endpoint string) (aws.Config, error) {
+	opts := awsConfigOptions(region, endpoint, conf.insecure)
+	webIdentity := conf.awsWebIdentityTokenFile != "" && conf.awsWebIdentityRoleArn != ""
+	roles := conf.awsAssumeRoles()
 
 	// 1. access keys take priority
-	// 2. next is an explicit assume role configuration. If web identity is also
+	// 2. next is an explicit assume role configuration, each role of an
+	//    assume_role chain being assumed with the credentials of the previous
+	//    one. If web identity is also
 	//    configured, its credentials are resolved first and used as the base for
-	//    the assume-role call (role chaining); otherwise the base session uses
+	//    the assume-role call (role chaining); otherwise the base config uses
 	//    the standard credential chain, which also resolves IRSA env vars.
 	// 3. next is an assume role via web identity (e.g. EKS IRSA). The role ARN
 	//    and token file come from aws_web_identity_role_arn / aws_web_identity_token_file,
@@ -679,174 +1254,162 @@ func awsSession(region string, conf *ProviderConf, endpoint string) *awssession.
 	//
 	// note: if #1 is chosen, then no further providers will be tested, since we've overridden the credentials with just a static provider
 	if conf.awsAccessKeyId != "" {
-		sessOpts.Config.Credentials = awscredentials.NewStaticCredentials(conf.awsAccessKeyId, conf.awsSecretAccessKey, conf.awsSessionToken)
-	} else if conf.awsAssumeRoleArn != "" {
-		var base *awscredentials.Credentials
-		if conf.awsWebIdentityTokenFile != "" && conf.awsWebIdentityRoleArn != "" {
-			base = assumeRoleWithWebIdentityCredentials(region, conf.awsWebIdentityRoleArn, conf.awsAssumeRoleSessionName, conf.awsWebIdentityTokenFile, endpoint)
-		}
-		sessOpts.Config.Credentials = assumeRoleCredentials(region, conf.awsAssumeRoleArn, conf.awsAssumeRoleExternalID, conf.awsAssumeRoleSessionName, conf.awsProfile, endpoint, base)
-	} else if conf.awsWebIdentityTokenFile != "" && conf.awsWebIdentityRoleArn != "" {
-		sessOpts.Config.Credentials = assumeRoleWithWebIdentityCredentials(region, conf.awsWebIdentityRoleArn, conf.awsAssumeRoleSessionName, conf.awsWebIdentityTokenFile, endpoint)
-	} else if conf.awsProfile != "" {
-		sessOpts.Profile = conf.awsProfile
+		opts = append(opts, awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(conf.awsAccessKeyId, conf.awsSecretAccessKey, conf.awsSessionToken)))
+	} else if conf.awsProfile != "" && (len(roles) > 0 || !webIdentity) {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(conf.awsProfile))
 	}
 
-	transport := http.Transport{}
-	// If configured as insecure, turn off SSL verification
-	if conf.insecure {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	} else if conf.hostOverride != "" {
-		// Only use `host_override` to set `ServerName` if we're using a secure connection
-		transport.TLSClientConfig = &tls.Config{ServerName: conf.hostOverride}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("error loading AWS configuration: %w", err)
+	}
+	if conf.awsAccessKeyId != "" {
+		return cfg, nil
 	}
 
-	client := &http.Client{Transport: &transport}
-	sessOpts.Config.HTTPClient = client
+	if webIdentity {
+		cfg.Credentials = assumeRoleWithWebIdentityCredentials(cfg, conf.awsWebIdentityRoleArn, conf.awsAssumeRoleSessionName, conf.awsWebIdentityTokenFile)
+	}
+	for _, role := range roles {
+		cfg.Credentials = assumeRoleCredentials(cfg, role, conf.awsAssumeRoleSessionName)
+	}
 
-	return awssession.Must(awssession.NewSessionWithOptions(sessOpts))
+	return cfg, nil
 }
 
 func awsHttpClient(region string, conf *ProviderConf, headers map[string]string) (*http.Client, error) {
-	session := awsSession(region, conf, "")
-	// Call Get() to ensure concurrency safe retrieval of credentials. Since the
-	// client is created in many go routines, this synchronizes it.
-	_, err := session.Config.Credentials.Get()
+	ctx := context.Background()
+	cfg, err := awsConfig(ctx, region, conf, "")
 	if err != nil {
 		return nil, err
 	}
-
-	// Set the proxy URL after configuring AWS credentials since the proxy
-	// should be not used for credential sources that call a URL like ECS Task
-	// Roles or EC2 Instance Roles.
-	if conf.proxy != "" {
-		proxyURL, _ := url.Parse(conf.proxy)
-		transport, _ := session.Config.HTTPClient.Transport.(*http.Transport)
-		transport.Proxy = http.ProxyURL(proxyURL)
-		session.Config.HTTPClient.Transport = transport
+	// Retrieve the credentials once so that missing ones are reported when
+	// the client is created rather than on the first request.
+	if _, err := cfg.Credentials.Retrieve(ctx); err != nil {
+		return nil, err
 	}
 
-	signer := awssigv4.NewSigner(session.Config.Credentials)
-	client, err := aws_signing_client.New(signer, session.Config.HTTPClient, conf.awsSig4Service, region)
+	// Requests to the cluster use their own transport rather than the one of
+	// the config, which is also used by credential sources that call a URL
+	// like STS, ECS Task Roles or EC2 Instance Roles: these shouldn't go
+	// through the proxy nor be verified against a custom CA.
+	transport, err := clusterTransport(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	rt := WithHeader(client.Transport)
+	rt := WithHeader(newAWSSigningTransport(transport, cfg.Credentials, conf.awsSig4Service, region))
 	rt.hostOverride = conf.hostOverride
 	for k, v := range headers {
 		rt.Set(k, v)
 	}
-	client.Transport = rt
 
-	return client, nil
+	return &http.Client{Transport: rt}, nil
 }
 
-func tokenHttpClient(conf *ProviderConf, headers map[string]string) *http.Client {
-	// Setup TLS options
-	tlsConfig := &tls.Config{}
-	if conf.insecure {
-		tlsConfig.InsecureSkipVerify = true
-	} else if conf.hostOverride != "" {
-		tlsConfig.ServerName = conf.hostOverride
-	}
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
 
-	// Wrapper to inject headers as needed
-	transport := &http.Transport{TLSClientConfig: tlsConfig}
-	// Configure a proxy URL if one is provided.
-	if conf.proxy != "" {
-		proxyURL, _ := url.Parse(conf.proxy)
-		transport.Proxy = http.ProxyURL(proxyURL)
+// tlsConfig returns the TLS configuration for connections to the cluster,
+// which is the same whatever the authentication method.
+func tlsConfig(conf *ProviderConf) (*tls.Config, error) {
+	config := &tls.Config{}
+	if err := loadTLSCertificates(conf, config); err != nil {
+		return nil, err
 	}
 
-	rt := WithHeader(transport)
-	rt.hostOverride = conf.hostOverride
-	rt.Set("Authorization", fmt.Sprintf("%s %s", conf.tokenName, conf.token))
-	for k, v := range headers {
-		rt.Set(k, v)
+	if conf.tlsMinVersion != "" {
+		config.MinVersion = tlsVersions[conf.tlsMinVersion]
 	}
 
-	client := &http.Client{Transport: rt}
+	// If configured as insecure, turn off SSL verification
+	if conf.insecure {
+		config.InsecureSkipVerify = true
+	} else if conf.hostOverride != "" {
+		// Only use `host_override` to set `ServerName` if we're using a secure connection
+		config.ServerName = conf.hostOverride
+	}
 
-	return client
+	return config, nil
 }
 
-func tlsHttpClient(conf *ProviderConf, headers map[string]string) *http.Client {
-	// Configure TLS/SSL
-	tlsConfig := &tls.Config{}
-	if conf.certPemPath != "" && conf.keyPemPath != "" {
+// loadTLSCertificates adds the client certificate and CA bundle, if
+// configured, to tlsConfig. Each may be given as a path or as PEM content.
+func loadTLSCertificates(conf *ProviderConf, tlsConfig *tls.Config) error {
+	if conf.certPemPath != "" || conf.keyPemPath != "" {
+		if conf.certPemPath == "" || conf.keyPemPath == "" {
+			return errors.New("client_cert_path and client_key_path must be set together")
+		}
 		certPem, _, err := readPathOrContent(conf.certPemPath)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("unable to read client_cert_path: %w", err)
 		}
 		keyPem, _, err := readPathOrContent(conf.keyPemPath)
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("unable to read client_key_path: %w", err)
 		}
 		cert, err := tls.X509KeyPair([]byte(certPem), []byte(keyPem))
 		if err != nil {
-			log.Fatal(err)
+			return fmt.Errorf("client_cert_path and client_key_path are not a valid key pair: %w", err)
 		}
 		tlsConfig.Certificates = []tls.Certificate{cert}
 	}
 
 	// If a cacertFile has been specified, use that for cert validation
 	if conf.cacertFile != "" {
-		caCert, _, _ := readPathOrContent(conf.cacertFile)
+		caCert, isPath, err := readPathOrContent(conf.cacertFile)
+		if err != nil {
+			return fmt.Errorf("unable to read cacert_file: %w", err)
+		}
 
 		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		if !caCertPool.AppendCertsFromPEM([]byte(caCert)) {
+			if !isPath {
+				return errors.New("cacert_file is neither a readable file nor PEM encoded certificates")
+			}
+			return fmt.Errorf("cacert_file %s does not contain any PEM encoded certificates", conf.cacertFile)
+		}
 		tlsConfig.RootCAs = caCertPool
 	}
 
-	// If configured as insecure, turn off SSL verification
-	if conf.insecure {
-		tlsConfig.InsecureSkipVerify = true
-	} else if conf.hostOverride != "" {
-		tlsConfig.ServerName = conf.hostOverride
-	}
+	return nil
+}
 
-	transport := &http.Transport{TLSClientConfig: tlsConfig}
-	// Configure a proxy URL if one is provided.
-	if conf.proxy != "" {
-		proxyURL, _ := url.Parse(conf.proxy)
-		transport.Proxy = http.ProxyURL(proxyURL)
+// clusterTransport returns a transport for connections to the cluster, with
+// the TLS configuration and proxy applied.
+func clusterTransport(conf *ProviderConf) (*http.Transport, error) {
+	config, err := tlsConfig(conf)
+	if err != nil {
+		return nil, err
 	}
 
-	rt := WithHeader(transport)
-	rt.hostOverride = conf.hostOverride
-	for k, v := range headers {
-		rt.Set(k, v)
+	transport := &http.Transport{TLSClientConfig: config}
+	httpConf := conf.http
+	if httpConf == nil {
+		httpConf = defaultHTTPConfig()
+	}
+	httpConf.apply(transport)
+	// Configure a proxy URL if one is provided.
+	if err := setProxy(transport, conf.proxy); err != nil {
+		return nil, err
 	}
 
-	client := &http.Client{Transport: rt}
-
-	return client
+	return transport, nil
 }
 
-func defaultHttpClient(conf *ProviderConf, headers map[string]string) *http.Client {
-	// Setup TLS options
-	tlsConfig := &tls.Config{}
-	if conf.insecure {
-		tlsConfig.InsecureSkipVerify = true
-	} else if conf.hostOverride != "" {
-		tlsConfig.ServerName = conf.hostOverride
-	}
-
-	transport := &http.Transport{TLSClientConfig: tlsConfig}
-	// Configure a proxy URL if one is provided.
-	if conf.proxy != "" {
-		proxyURL, _ := url.Parse(conf.proxy)
-		transport.Proxy = http.ProxyURL(proxyURL)
+// setProxy configures transport to send requests through proxyURL, if set.
+func setProxy(transport *http.Transport, proxyURL string) error {
+	if proxyURL == "" {
+		return nil
 	}
-
-	// Wrapper to inject headers as needed
-	rt := WithHeader(transport)
-	rt.hostOverride = conf.hostOverride
-	for k, v := range headers {
-		rt.Set(k, v)
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
 	}
-
-	client := &http.Client{Transport: rt}
-	return client
+	transport.Proxy = http.ProxyURL(u)
+	return nil
 }
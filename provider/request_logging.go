@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	redactedValue = "***"
+	// bodies larger than this are not logged
+	maxLoggedBodySize = 64 * 1024
+)
+
+// Keys whose values are always redacted from logged bodies, matched
+// case-insensitively anywhere in the document. A key matches if it contains
+// one of these, e.g. `opendistro_security_password` or `credentials`.
+var redactedKeySubstrings = []string{"password", "credential", "authorization", "secret"}
+
+// Keys whose values are always redacted, matched exactly (case-insensitively).
+var redactedKeys = []string{"hash"}
+
+// Request headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Amz-Security-Token"}
+
+// requestLoggingConfig controls the structured request log. Requests are
+// always logged, bodies only when includeBody is set.
+type requestLoggingConfig struct {
+	includeBody bool
+	// dotted JSON paths redacted in addition to the built-in keys, e.g.
+	// `parameters.api_key`. Array indexes are not part of the path.
+	redactPaths []string
+}
+
+func expandRequestLoggingConfig(raw []interface{}) *requestLoggingConfig {
+	if len(raw) == 0 || raw[0] == nil {
+		return &requestLoggingConfig{}
+	}
+	m := raw[0].(map[string]interface{})
+
+	return &requestLoggingConfig{
+		includeBody: m["include_body"].(bool),
+		redactPaths: expandStringList(m["redact_paths"].([]interface{})),
+	}
+}
+
+// requestLogTransport logs every request sent to the cluster with its method,
+// path, status and latency, and optionally the request and response bodies
+// with secrets redacted. Entries are written through tflog so that they show
+// up in the standard Terraform logs, at DEBUG level.
+type requestLogTransport struct {
+	rt     http.RoundTripper
+	config *requestLoggingConfig
+	// context carrying the provider logger, as request contexts often don't
+	ctx context.Context
+}
+
+func (t *requestLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	config := t.config
+	if config == nil {
+		config = &requestLoggingConfig{}
+	}
+
+	fields := map[string]interface{}{
+		"http_method":  req.Method,
+		"http_path":    req.URL.Path,
+		"http_host":    req.URL.Host,
+		"http_headers": redactHeaders(req.Header),
+	}
+	if req.URL.RawQuery != "" {
+		fields["http_query"] = req.URL.RawQuery
+	}
+
+	if config.includeBody && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http_request_body"] = redactBody(body, config.redactPaths)
+	}
+
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "OpenSearch request failed", fields)
+		return res, err
+	}
+
+	fields["http_status"] = res.StatusCode
+	if config.includeBody && res.Body != nil {
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		fields["http_response_body"] = redactBody(body, config.redactPaths)
+	}
+
+	tflog.Debug(ctx, "OpenSearch request", fields)
+
+	return res, nil
+}
+
+// nonEmptyStrings returns the given strings except empty ones, which would
+// otherwise match anywhere when masking log fields.
+func nonEmptyStrings(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for k, v := range header {
+		value := strings.Join(v, ", ")
+		for _, h := range redactedHeaders {
+			if strings.EqualFold(k, h) {
+				value = redactedValue
+			}
+		}
+		redacted[k] = value
+	}
+	return redacted
+}
+
+// redactBody returns a JSON body as a string with secrets redacted. Bodies
+// that are not a single JSON document, e.g. NDJSON, are omitted since they
+// can't be redacted reliably.
+func redactBody(body []byte, paths []string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if len(body) > maxLoggedBodySize {
+		return fmt.Sprintf("[%d bytes omitted]", len(body))
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return fmt.Sprintf("[%d bytes of non-JSON content omitted]", len(body))
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, "", paths))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes omitted]", len(body))
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}, path string, paths []string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if isRedactedKey(k) || containsString(paths, childPath) {
+				v[k] = redactedValue
+				continue
+			}
+			v[k] = redactValue(child, childPath, paths)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child, path, paths)
+		}
+	}
+	return v
+}
+
+func isRedactedKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range redactedKeySubstrings {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return containsString(redactedKeys, key)
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status":"CREATED","hash":"$2y$12$abc"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := &http.Client{Transport: &requestLogTransport{
+		rt:     http.DefaultTransport,
+		config: &requestLoggingConfig{includeBody: true, redactPaths: []string{"attributes.api_key"}},
+		ctx:    ctx,
+	}}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/_plugins/_security/api/internalusers/jdoe", strings.NewReader(`{"password":"hunter2","attributes":{"api_key":"k3y","team":"search"}}`))
+	req.Header.Set("Authorization", "Basic amRvZTpodW50ZXIy")
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(body), "$2y$12$abc") {
+		t.Errorf("expected the response body to remain readable, got %q", body)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d: %v", len(entries), entries)
+	}
+	entry := entries[0]
+
+	if entry["http_method"] != http.MethodPut || entry["http_path"] != "/_plugins/_security/api/internalusers/jdoe" {
+		t.Errorf("unexpected method or path: %v", entry)
+	}
+	if entry["http_status"] != float64(http.StatusCreated) {
+		t.Errorf("http_status: got %v, want %d", entry["http_status"], http.StatusCreated)
+	}
+	if _, ok := entry["http_duration_ms"]; !ok {
+		t.Errorf("expected the latency to be logged: %v", entry)
+	}
+	if got := entry["http_headers"].(map[string]interface{})["Authorization"]; got != redactedValue {
+		t.Errorf("expected the Authorization header to be redacted, got %v", got)
+	}
+
+	logged := output.String()
+	for _, secret := range []string{"hunter2", "k3y", "$2y$12$abc", "amRvZTpodW50ZXIy"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected %q to be redacted from the log: %s", secret, logged)
+		}
+	}
+	if !strings.Contains(entry["http_request_body"].(string), "search") {
+		t.Errorf("expected non-secret values to be logged, got %v", entry["http_request_body"])
+	}
+}
+
+func TestRequestLogTransport_OmitsBodiesByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	client := &http.Client{Transport: &requestLogTransport{
+		rt:     http.DefaultTransport,
+		config: expandRequestLoggingConfig(nil),
+		ctx:    tflogtest.RootLogger(context.Background(), &output),
+	}}
+
+	res, err := client.Post(server.URL+"/test-index", "application/json", strings.NewReader(`{"settings":{}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode log output: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	for _, field := range []string{"http_request_body", "http_response_body"} {
+		if _, ok := entries[0][field]; ok {
+			t.Errorf("expected %s not to be logged without include_body", field)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		name  string
+		body  string
+		paths []string
+		want  string
+	}{
+		{
+			name: "built-in keys",
+			body: `{"opendistro_security_password":"p","credential":{"access_key":"a"},"hash":"h","hashes":1}`,
+			want: `{"credential":"***","hash":"***","hashes":1,"opendistro_security_password":"***"}`,
+		},
+		{
+			name:  "configured paths",
+			body:  `{"parameters":{"api_key":"k","endpoint":"e"},"actions":[{"headers":{"x-api-key":"k"}}]}`,
+			paths: []string{"parameters.api_key", "actions.headers"},
+			want:  `{"actions":[{"headers":"***"}],"parameters":{"api_key":"***","endpoint":"e"}}`,
+		},
+		{
+			name: "not JSON",
+			body: "{\"index\":{}}\n{\"field\":1}\n",
+			want: "[25 bytes of non-JSON content omitted]",
+		},
+		{
+			name: "empty",
+			body: "",
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := redactBody([]byte(tc.body), tc.paths); got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
@@ -25,16 +25,29 @@ var anomalyDetectionSchema = map[string]*schema.Schema{
 		},
 		ValidateFunc: validation.StringIsJSON,
 	},
+	"primary_term": {
+		Description: "The primary term of the anomaly detector version.",
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+	},
+	"seq_no": {
+		Description: "The sequence number of the anomaly detector version.",
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchAnomalyDetection() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch anonaly detection. Please refer to the OpenSearch anomaly detection documentation for details.",
-		Create:      resourceOpensearchAnomalyDetectionCreate,
-		Read:        resourceOpensearchAnomalyDetectionRead,
-		Update:      resourceOpensearchAnomalyDetectionUpdate,
-		Delete:      resourceOpensearchAnomalyDetectionDelete,
-		Schema:      anomalyDetectionSchema,
+		Description:   "Provides an OpenSearch anonaly detection. Please refer to the OpenSearch anomaly detection documentation for details.",
+		Create:        resourceOpensearchAnomalyDetectionCreate,
+		Read:          resourceOpensearchAnomalyDetectionRead,
+		Update:        resourceOpensearchAnomalyDetectionUpdate,
+		Delete:        resourceOpensearchAnomalyDetectionDelete,
+		CustomizeDiff: requireCapabilities(capabilityAnomalyDetection, nil),
+		Schema:        anomalyDetectionSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -58,7 +71,7 @@ func resourceOpensearchAnomalyDetectionCreate(d *schema.ResourceData, m interfac
 func resourceOpensearchAnomalyDetectionRead(d *schema.ResourceData, m interface{}) error {
 	res, err := resourceOpensearchAnomalyDetectionGet(d.Id(), m)
 
-	if elastic7.IsNotFound(err) {
+	if isNotFound(err) {
 		log.Printf("[WARN] Anomaly Detector (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -78,6 +91,12 @@ func resourceOpensearchAnomalyDetectionRead(d *schema.ResourceData, m interface{
 	if err != nil {
 		return err
 	}
+	if err := d.Set("primary_term", res.PrimaryTerm); err != nil {
+		return fmt.Errorf("error setting primary_term: %s", err)
+	}
+	if err := d.Set("seq_no", res.SeqNo); err != nil {
+		return fmt.Errorf("error setting seq_no: %s", err)
+	}
 	err = d.Set("body", anomalyDetectionJsonNormalized)
 	return err
 }
@@ -181,10 +200,11 @@ func resourceOpensearchPutAnomalyDetection(d *schema.ResourceData, m interface{}
 	res, err = osClient.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 		Method: "PUT",
 		Path:   path,
+		Params: versionParams(d),
 		Body:   anomalyDetectionJSON,
 	})
 	if err != nil {
-		return response, err
+		return response, versionConflictError("anomaly detector", d.Id(), err)
 	}
 	body = res.Body
 
@@ -220,5 +240,7 @@ func resourceOpensearchAnomalyDetectionDelete(d *schema.ResourceData, m interfac
 type anomalyDetectionResponse struct {
 	Version         int                    `json:"_version"`
 	ID              string                 `json:"_id"`
+	PrimaryTerm     int                    `json:"_primary_term"`
+	SeqNo           int                    `json:"_seq_no"`
 	AnomalyDetector map[string]interface{} `json:"anomaly_detector"`
 }
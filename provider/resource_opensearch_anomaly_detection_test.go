@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchAnomalyDetection(t *testing.T) {
@@ -34,6 +35,18 @@ func TestAccOpensearchAnomalyDetection(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchAnomalyDetection(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_anomaly_detection.test-detector12", "/_plugins/_anomaly_detection/detectors/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchAnomalyDetection,
+		},
+		resource.TestStep{
+			Config: testAccOpensearchAnomalyDetectionUpdate,
+		},
+	))
+}
+
 func testCheckOpensearchAnomalyDetectionExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -176,11 +176,12 @@ var auditConfigSchema = map[string]*schema.Schema{
 
 func resourceOpenSearchAuditConfig() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpensearchAuditConfigCreate,
-		Read:   resourceOpensearchAuditConfigRead,
-		Update: resourceOpensearchAuditConfigUpdate,
-		Delete: resourceOpensearchAuditConfigDelete,
-		Schema: auditConfigSchema,
+		Create:        resourceOpensearchAuditConfigCreate,
+		Read:          resourceOpensearchAuditConfigRead,
+		Update:        resourceOpensearchAuditConfigUpdate,
+		Delete:        resourceOpensearchAuditConfigDelete,
+		CustomizeDiff: requireCapabilities(capabilitySecurityAudit, nil),
+		Schema:        auditConfigSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -200,7 +201,7 @@ func resourceOpensearchAuditConfigRead(d *schema.ResourceData, m interface{}) er
 
 	res, err := resourceOpensearchGetAuditConfig(m)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] audit config (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
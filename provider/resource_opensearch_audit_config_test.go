@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenSearchSecurityAuditConfig(t *testing.T) {
@@ -51,6 +52,34 @@ func TestAccOpensearchOpenSearchSecurityAuditConfig(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchSecurityAuditConfig(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpenSearchSecurityAuditConfigResource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "audit.#", "1"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "audit.0.enable_rest", "true"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "audit.0.disabled_rest_categories.#", "2"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "compliance.#", "1"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "compliance.0.enabled", "true"),
+				),
+			},
+			{
+				Config: testAccOpenSearchSecurityAuditConfigResourceUpdated(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("opensearch_audit_config.test", "audit.0.disabled_rest_categories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccOpenSearchSecurityAuditConfigResource() string {
 	return `
 resource "opensearch_audit_config" "test" {
@@ -26,16 +26,22 @@ var openDistroChannelConfigurationSchema = map[string]*schema.Schema{
 		},
 		ValidateFunc: validation.StringIsJSON,
 	},
+	"etag": {
+		Description: "Hash of the channel configuration as last read, compared with the channel configuration on the cluster before an update to detect changes made since the last refresh.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchChannelConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch channel configuration. Please refer to the OpenSearch channel configuration documentation for details.",
-		Create:      resourceOpensearchOpenDistroChannelConfigurationCreate,
-		Read:        resourceOpensearchOpenDistroChannelConfigurationRead,
-		Update:      resourceOpensearchOpenDistroChannelConfigurationUpdate,
-		Delete:      resourceOpensearchOpenDistroChannelConfigurationDelete,
-		Schema:      openDistroChannelConfigurationSchema,
+		Description:   "Provides an OpenSearch channel configuration. Please refer to the OpenSearch channel configuration documentation for details.",
+		Create:        resourceOpensearchOpenDistroChannelConfigurationCreate,
+		Read:          resourceOpensearchOpenDistroChannelConfigurationRead,
+		Update:        resourceOpensearchOpenDistroChannelConfigurationUpdate,
+		Delete:        resourceOpensearchOpenDistroChannelConfigurationDelete,
+		CustomizeDiff: requireCapabilities(capabilityNotifications, nil),
+		Schema:        openDistroChannelConfigurationSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -59,7 +65,7 @@ func resourceOpensearchOpenDistroChannelConfigurationCreate(d *schema.ResourceDa
 func resourceOpensearchOpenDistroChannelConfigurationRead(d *schema.ResourceData, m interface{}) error {
 	res, err := resourceOpensearchOpenDistroGetChannelConfiguration(d.Id(), m)
 
-	if elastic7.IsNotFound(err) {
+	if isNotFound(err) {
 		log.Printf("[WARN] Channel configuration (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -79,12 +85,28 @@ func resourceOpensearchOpenDistroChannelConfigurationRead(d *schema.ResourceData
 	if err != nil {
 		return err
 	}
+	etag, err := objectETag(res.ChannelConfigurationInfos[0])
+	if err != nil {
+		return err
+	}
+	if err := d.Set("etag", etag); err != nil {
+		return fmt.Errorf("error setting etag: %s", err)
+	}
 	err = d.Set("body", channelConfigurationJsonNormalized)
 	return err
 }
 
 func resourceOpensearchOpenDistroChannelConfigurationUpdate(d *schema.ResourceData, m interface{}) error {
-	_, err := resourceOpensearchOpenDistroPutChannelConfiguration(d, m)
+	current, err := resourceOpensearchOpenDistroGetChannelConfiguration(d.Id(), m)
+	var config map[string]interface{}
+	if err == nil {
+		config = current.ChannelConfigurationInfos[0]
+	}
+	if err := checkETag(d, "channel configuration", config, err); err != nil {
+		return err
+	}
+
+	_, err = resourceOpensearchOpenDistroPutChannelConfiguration(d, m)
 
 	if err != nil {
 		return err
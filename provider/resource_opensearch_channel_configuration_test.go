@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroChannelConfiguration(t *testing.T) {
@@ -73,6 +74,70 @@ func TestAccOpensearchOpenDistroChannelConfiguration(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchChannelConfiguration(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchOpenDistroWebhookChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.webhook_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroSlackChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.slack_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroChimeChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.chime_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroSnsChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.sns_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroSmtpEmailChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.smtp_account_configuration", "/_plugins/_notifications/configs/{id}"),
+					testUnitCheckExists(server, "opensearch_channel_configuration.email_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroSesEmailChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.ses_account_configuration", "/_plugins/_notifications/configs/{id}"),
+					testUnitCheckExists(server, "opensearch_channel_configuration.email_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccOpensearchOpenDistroSesEmailGroupChannelConfiguration,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_channel_configuration.ses_account_configuration", "/_plugins/_notifications/configs/{id}"),
+					testUnitCheckExists(server, "opensearch_channel_configuration.email_group_configuration", "/_plugins/_notifications/configs/{id}"),
+					testUnitCheckExists(server, "opensearch_channel_configuration.email_channel_configuration", "/_plugins/_notifications/configs/{id}"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testCheckOpensearchChannelConfigurationExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -76,11 +76,12 @@ var (
 
 func resourceOpensearchClusterSettings() *schema.Resource {
 	return &schema.Resource{
-		Description: "Manages a cluster's (persistent) settings.",
-		Create:      resourceOpensearchClusterSettingsCreate,
-		Read:        resourceOpensearchClusterSettingsRead,
-		Update:      resourceOpensearchClusterSettingsUpdate,
-		Delete:      resourceOpensearchClusterSettingsDelete,
+		Description:   "Manages a cluster's (persistent) settings.",
+		Create:        resourceOpensearchClusterSettingsCreate,
+		Read:          resourceOpensearchClusterSettingsRead,
+		Update:        resourceOpensearchClusterSettingsUpdate,
+		Delete:        resourceOpensearchClusterSettingsDelete,
+		CustomizeDiff: requireCapabilities(capabilityClusterSettings, nil),
 		Schema: map[string]*schema.Schema{
 			"cluster_max_shards_per_node": {
 				Type:        schema.TypeInt,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchClusterSettings(t *testing.T) {
@@ -26,6 +27,23 @@ func TestAccOpensearchClusterSettings(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchClusterSettings(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchClusterSettings,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckOpensearchClusterSettingInState("opensearch_cluster_settings.global"),
+					resource.TestCheckResourceAttr("opensearch_cluster_settings.global", "action_auto_create_index", "my-index-000001,index10,-index1*,+ind*,-.aws_cold_catalog*,+*"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccOpensearchClusterSettingsSlowLogs(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -60,7 +60,7 @@ func resourceOpensearchComponentTemplateRead(d *schema.ResourceData, meta interf
 
 	result, err = elastic7GetComponentTemplate(osClient, id)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] Index template (%s) not found, removing from state", id)
 			d.SetId("")
 			return nil
@@ -69,6 +69,13 @@ func resourceOpensearchComponentTemplateRead(d *schema.ResourceData, meta interf
 		return err
 	}
 
+	if providerConf.isServerless() {
+		result, err = stripServerlessManagedTemplateSettings(result)
+		if err != nil {
+			return err
+		}
+	}
+
 	ds := &resourceDataSetter{d: d}
 	ds.set("name", d.Id())
 	ds.set("body", result)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchComponentTemplate(t *testing.T) {
@@ -36,6 +37,15 @@ func TestAccOpensearchComponentTemplate(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchComponentTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_component_template.test", "/_component_template/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchComponentTemplate,
+		},
+	))
+}
+
 func TestAccOpensearchComponentTemplate_importBasic(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
@@ -59,7 +59,7 @@ func resourceOpensearchComposableIndexTemplateRead(d *schema.ResourceData, meta
 	}
 	result, err = elastic7GetIndexTemplate(osClient, id)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] Index template (%s) not found, removing from state", id)
 			d.SetId("")
 			return nil
@@ -68,6 +68,13 @@ func resourceOpensearchComposableIndexTemplateRead(d *schema.ResourceData, meta
 		return err
 	}
 
+	if providerConf.isServerless() {
+		result, err = stripServerlessManagedTemplateSettings(result)
+		if err != nil {
+			return err
+		}
+	}
+
 	ds := &resourceDataSetter{d: d}
 	ds.set("name", d.Id())
 	ds.set("body", result)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchComposableIndexTemplate(t *testing.T) {
@@ -37,6 +38,15 @@ func TestAccOpensearchComposableIndexTemplate(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchComposableIndexTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_composable_index_template.test", "/_index_template/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchComposableIndexTemplate,
+		},
+	))
+}
+
 func TestAccOpensearchComposableIndexTemplate_importBasic(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
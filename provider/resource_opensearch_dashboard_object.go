@@ -139,7 +139,7 @@ func resourceOpensearchDashboardObjectRead(d *schema.ResourceData, meta interfac
 	// fetch object from OpenSearch
 	result, err := state.elastic7GetDashboardObject(client)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] Dashboard Object (%s) not found, removing from state", state.id)
 			d.SetId("")
 			return nil
@@ -365,7 +365,7 @@ func (s *dashboardObjectState) elastic7GetDashboardObject(client *elastic7.Clien
 		req = req.Header(SECURITY_TENANT_HEADER, s.tenantName)
 	}
 	result, err := req.Do(context.TODO())
-	if elastic7.IsNotFound(err) {
+	if isNotFound(err) {
 		return nil, err // there is a check against this error
 	}
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchDashboardObject(t *testing.T) {
@@ -50,6 +51,34 @@ func TestAccOpensearchDashboardObject(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchDashboardObject(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_dashboard_object", "/{index}/_doc/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearch7DashboardVisualization,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_dashboard_object.test_visualization", "/{index}/_doc/{id}"),
+				),
+			},
+			{
+				Config: testAccOpensearch7DashboardIndexPattern,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckExists(server, "opensearch_dashboard_object.test_pattern", "/{index}/_doc/{id}"),
+				),
+			},
+			{
+				Config:             testAccOpensearch7DashboardIndexPattern,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
 func TestAccOpensearchDashboardObjectWithTenant(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
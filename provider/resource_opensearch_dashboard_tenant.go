@@ -32,15 +32,21 @@ var openSearchDashboardTenantSchema = map[string]*schema.Schema{
 		Type:     schema.TypeString,
 		Computed: true,
 	},
+	"etag": {
+		Description: "Hash of the tenant as last read, compared with the tenant on the cluster before an update to detect changes made since the last refresh.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchDashboardTenant() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpensearchOpenDistroDashboardTenantCreate,
-		Read:   resourceOpensearchOpenDistroDashboardTenantRead,
-		Update: resourceOpensearchOpenDistroDashboardTenantUpdate,
-		Delete: resourceOpensearchOpenDistroDashboardTenantDelete,
-		Schema: openSearchDashboardTenantSchema,
+		Create:        resourceOpensearchOpenDistroDashboardTenantCreate,
+		Read:          resourceOpensearchOpenDistroDashboardTenantRead,
+		Update:        resourceOpensearchOpenDistroDashboardTenantUpdate,
+		Delete:        resourceOpensearchOpenDistroDashboardTenantDelete,
+		CustomizeDiff: requireCapabilities(capabilitySecurityTenants, nil),
+		Schema:        openSearchDashboardTenantSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -63,7 +69,7 @@ func resourceOpensearchOpenDistroDashboardTenantRead(d *schema.ResourceData, m i
 	res, err := resourceOpensearchGetOpenDistroDashboardTenant(d.Id(), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpenDistroDashboardTenant (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -85,6 +91,13 @@ func resourceOpensearchOpenDistroDashboardTenantRead(d *schema.ResourceData, m i
 	if err := d.Set("index", index); err != nil {
 		return fmt.Errorf("error setting index: %s", err)
 	}
+	etag, err := objectETag(res)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("etag", etag); err != nil {
+		return fmt.Errorf("error setting etag: %s", err)
+	}
 
 	return nil
 }
@@ -108,6 +121,11 @@ func resourceOpensearchOpenDistroDashboardComputeIndex(tenant string) (string, e
 }
 
 func resourceOpensearchOpenDistroDashboardTenantUpdate(d *schema.ResourceData, m interface{}) error {
+	current, err := resourceOpensearchGetOpenDistroDashboardTenant(d.Id(), m)
+	if err := checkETag(d, "tenant", current, err); err != nil {
+		return err
+	}
+
 	if _, err := resourceOpensearchPutOpenDistroDashboardTenant(d, m); err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroDashboardTenant(t *testing.T) {
@@ -61,6 +62,26 @@ func TestAccOpensearchOpenDistroDashboardTenant(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchDashboardTenant(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_dashboard_tenant.test", "/_plugins/_security/api/tenants/{id}",
+		resource.TestStep{
+			Config: testAccOpenDistroDashboardTenantResource(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_dashboard_tenant.test", "id", randomName),
+				resource.TestCheckResourceAttr("opensearch_dashboard_tenant.test", "description", "test"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroDashboardTenantResourceUpdated(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_dashboard_tenant.test", "description", "test2"),
+			),
+		},
+	))
+}
+
 func testAccCheckOpensearchDashboardTenantDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "opensearch_dashboard_tenant" {
@@ -50,7 +50,7 @@ func resourceOpensearchDataStreamRead(d *schema.ResourceData, meta interface{})
 	}
 	err = elastic7GetDataStream(osClient, id)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] data stream (%s) not found, removing from state", id)
 			d.SetId("")
 			return nil
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchDataStream(t *testing.T) {
@@ -37,6 +38,15 @@ func TestAccOpensearchDataStream(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchDataStream(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_data_stream.foo", "/_data_stream/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchDataStream,
+		},
+	))
+}
+
 func testCheckOpensearchDataStreamExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -429,12 +429,13 @@ var (
 
 func resourceOpensearchIndex() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch index resource.",
-		Create:      resourceOpensearchIndexCreate,
-		Read:        resourceOpensearchIndexRead,
-		Update:      resourceOpensearchIndexUpdate,
-		Delete:      resourceOpensearchIndexDelete,
-		Schema:      configSchema,
+		Description:   "Provides an OpenSearch index resource.",
+		Create:        resourceOpensearchIndexCreate,
+		Read:          resourceOpensearchIndexRead,
+		Update:        resourceOpensearchIndexUpdate,
+		Delete:        resourceOpensearchIndexDelete,
+		CustomizeDiff: requireCapabilities(capability{}, serverlessIndexAttributes()),
+		Schema:        configSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -760,7 +761,7 @@ func resourceOpensearchIndexRead(d *schema.ResourceData, meta interface{}) error
 	}
 	r, err := osClient.IndexGetSettings(index).FlatSettings(true).Do(ctx)
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] Index (%s) not found, removing from state", index)
 			d.SetId("")
 			return nil
@@ -803,6 +804,16 @@ func resourceOpensearchIndexRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	// Settings managed by Amazon OpenSearch Serverless can't be configured,
+	// so they would only show up as a difference.
+	if meta.(*ProviderConf).isServerless() {
+		for k := range settings {
+			if isServerlessManagedIndexSetting(k) {
+				delete(settings, k)
+			}
+		}
+	}
+
 	indexResourceDataFromSettings(settings, d)
 
 	var response *json.RawMessage
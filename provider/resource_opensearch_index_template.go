@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	elastic7 "github.com/olivere/elastic/v7"
-	elastic6 "gopkg.in/olivere/elastic.v6"
 )
 
 func resourceOpensearchIndexTemplate() *schema.Resource {
@@ -59,7 +58,7 @@ func resourceOpensearchIndexTemplateRead(d *schema.ResourceData, meta interface{
 	}
 	result, err = elastic7IndexGetTemplate(osClient, id)
 	if err != nil {
-		if elastic7.IsNotFound(err) || elastic6.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] Index template (%s) not found, removing from state", id)
 			d.SetId("")
 			return nil
@@ -68,6 +67,13 @@ func resourceOpensearchIndexTemplateRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
+	if meta.(*ProviderConf).isServerless() {
+		result, err = stripServerlessManagedTemplateSettings(result)
+		if err != nil {
+			return err
+		}
+	}
+
 	ds := &resourceDataSetter{d: d}
 	ds.set("name", d.Id())
 	ds.set("body", result)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchIndexTemplate(t *testing.T) {
@@ -33,6 +34,15 @@ func TestAccOpensearchIndexTemplate(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchIndexTemplate(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_index_template.test", "/_index_template/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchIndexTemplateV7,
+		},
+	))
+}
+
 func TestAccOpensearchIndexTemplate_importBasic(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 const (
@@ -344,6 +345,31 @@ func TestAccOpensearchIndex(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchIndex(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_index.test", "/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchIndex,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_index.test", "number_of_replicas", "1"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpensearchIndexUpdate1,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_index.test", "number_of_replicas", "2"),
+				resource.TestCheckResourceAttr("opensearch_index.test", "refresh_interval", "10s"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpensearchIndexUpdateForceDestroy,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_index.test", "force_destroy", "true"),
+			),
+		},
+	))
+}
+
 func TestAccOpensearchIndexDefaultShardsReplicas(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -58,6 +59,11 @@ func resourceOpensearchIngestPipelineRead(d *schema.ResourceData, meta interface
 	}
 	result, err = elastic7IngestGetPipeline(osClient, id)
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Ingest pipeline (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
@@ -69,7 +75,7 @@ func resourceOpensearchIngestPipelineRead(d *schema.ResourceData, meta interface
 
 func elastic7IngestGetPipeline(client *elastic7.Client, id string) (string, error) {
 
-	res, err := client.IngestGetPipeline().Pretty(false).Do(context.TODO())
+	res, err := client.IngestGetPipeline(id).Pretty(false).Do(context.TODO())
 	if err != nil {
 		return "", err
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchIngestPipeline(t *testing.T) {
@@ -34,6 +35,15 @@ func TestAccOpensearchIngestPipeline(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchIngestPipeline(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_ingest_pipeline.test", "/_ingest/pipeline/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchIngestPipelineV7,
+		},
+	))
+}
+
 func TestAccOpensearchIngestPipeline_importBasic(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
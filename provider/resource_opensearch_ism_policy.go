@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"net/url"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -50,12 +48,13 @@ var openSearchISMPolicySchema = map[string]*schema.Schema{
 
 func resourceOpenSearchISMPolicy() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch Index State Management (ISM) policy. Please refer to the OpenSearch ISM documentation for details.",
-		Create:      resourceOpensearchISMPolicyCreate,
-		Read:        resourceOpensearchISMPolicyRead,
-		Update:      resourceOpensearchISMPolicyUpdate,
-		Delete:      resourceOpensearchISMPolicyDelete,
-		Schema:      openSearchISMPolicySchema,
+		Description:   "Provides an OpenSearch Index State Management (ISM) policy. Please refer to the OpenSearch ISM documentation for details.",
+		Create:        resourceOpensearchISMPolicyCreate,
+		Read:          resourceOpensearchISMPolicyRead,
+		Update:        resourceOpensearchISMPolicyUpdate,
+		Delete:        resourceOpensearchISMPolicyDelete,
+		CustomizeDiff: requireCapabilities(capabilityISM, nil),
+		Schema:        openSearchISMPolicySchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -77,7 +76,7 @@ func resourceOpensearchISMPolicyRead(d *schema.ResourceData, m interface{}) erro
 	policyResponse, err := resourceOpensearchGetISMPolicy(d.Id(), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpenSearch Policy (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -139,11 +138,11 @@ func resourceOpensearchISMPolicyDelete(d *schema.ResourceData, m interface{}) er
 	})
 
 	if err != nil {
-		return fmt.Errorf("error deleting policy: %+v : %+v", path, err)
+		return fmt.Errorf("error deleting policy: %+v : %w", path, err)
 	}
 
 	if err != nil {
-		return fmt.Errorf("error deleting policy: %+v : %+v", path, err)
+		return fmt.Errorf("error deleting policy: %+v : %w", path, err)
 	}
 
 	return err
@@ -173,7 +172,7 @@ func resourceOpensearchGetISMPolicy(policyID string, m interface{}) (GetPolicyRe
 	})
 
 	if err != nil {
-		return *response, fmt.Errorf("error getting policy: %+v : %+v", path, err)
+		return *response, fmt.Errorf("error getting policy: %+v : %w", path, err)
 	}
 	body = &res.Body
 
@@ -193,14 +192,6 @@ func resourceOpensearchGetISMPolicy(policyID string, m interface{}) (GetPolicyRe
 func resourceOpensearchPutISMPolicy(d *schema.ResourceData, m interface{}) (*PutPolicyResponse, error) {
 	response := new(PutPolicyResponse)
 	policyJSON := d.Get("body").(string)
-	seq := d.Get("seq_no").(int)
-	primTerm := d.Get("primary_term").(int)
-	params := url.Values{}
-
-	if seq >= 0 && primTerm > 0 {
-		params.Set("if_seq_no", strconv.Itoa(seq))
-		params.Set("if_primary_term", strconv.Itoa(primTerm))
-	}
 
 	path, err := uritemplates.Expand("/_plugins/_ism/policies/{policy_id}", map[string]string{
 		"policy_id": d.Get("policy_id").(string),
@@ -214,19 +205,21 @@ func resourceOpensearchPutISMPolicy(d *schema.ResourceData, m interface{}) (*Put
 	if err != nil {
 		return nil, err
 	}
+	params := versionParams(d)
 	var res *elastic7.Response
 	res, err = osclient.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 		Method:           "PUT",
 		Path:             path,
 		Params:           params,
 		Body:             string(policyJSON),
-		RetryStatusCodes: []int{http.StatusConflict},
+		RetryStatusCodes: conflictRetryStatusCodes(params),
 		Retrier: elastic7.NewBackoffRetrier(
 			elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
 		),
 	})
 	if err != nil {
-		return response, fmt.Errorf("error putting policy: %+v : %+v : %+v", path, policyJSON, err)
+		err = fmt.Errorf("error putting policy: %+v : %+v : %w", path, policyJSON, err)
+		return response, versionConflictError("ISM policy", d.Get("policy_id").(string), err)
 	}
 	body = &res.Body
 
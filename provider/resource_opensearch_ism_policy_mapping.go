@@ -53,12 +53,13 @@ var openDistroISMPolicyMappingSchema = map[string]*schema.Schema{
 
 func resourceOpenSearchISMPolicyMapping() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch Index State Management (ISM) policy. Please refer to the OpenSearch ISM documentation for details.\n\n!> `opensearch_ism_policy_mapping` is deprecated in OpenSearch 1.x please use the `opensearch_ism_policy` resource and specify the `ism_template` attribute in the policies instead.",
-		Create:      resourceOpensearchOpenDistroISMPolicyMappingCreate,
-		Read:        resourceOpensearchOpenDistroISMPolicyMappingRead,
-		Update:      resourceOpensearchOpenDistroISMPolicyMappingUpdate,
-		Delete:      resourceOpensearchOpenDistroISMPolicyMappingDelete,
-		Schema:      openDistroISMPolicyMappingSchema,
+		Description:   "Provides an OpenSearch Index State Management (ISM) policy. Please refer to the OpenSearch ISM documentation for details.\n\n!> `opensearch_ism_policy_mapping` is deprecated in OpenSearch 1.x please use the `opensearch_ism_policy` resource and specify the `ism_template` attribute in the policies instead.",
+		Create:        resourceOpensearchOpenDistroISMPolicyMappingCreate,
+		Read:          resourceOpensearchOpenDistroISMPolicyMappingRead,
+		Update:        resourceOpensearchOpenDistroISMPolicyMappingUpdate,
+		Delete:        resourceOpensearchOpenDistroISMPolicyMappingDelete,
+		CustomizeDiff: requireCapabilities(capabilityISMPolicyMapping, nil),
+		Schema:        openDistroISMPolicyMappingSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -143,6 +144,11 @@ func resourceOpensearchOpenDistroISMPolicyMappingRead(d *schema.ResourceData, m
 
 	indices, err := resourceOpensearchOpendistroPolicyIndices(indexPattern, policyID, m)
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] OpendistroPolicyMapping (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[INFO] resourceOpensearchOpenDistroISMPolicyMappingRead %+v %+v", indices, err)
 		return err
 	}
@@ -164,7 +170,7 @@ func resourceOpensearchOpenDistroISMPolicyMappingRead(d *schema.ResourceData, m
 
 func resourceOpensearchOpenDistroISMPolicyMappingUpdate(d *schema.ResourceData, m interface{}) error {
 	if _, err := resourceOpensearchPostOpendistroPolicyMapping(d, m, "change_policy"); err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpendistroPolicyMapping (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroISMPolicyMapping(t *testing.T) {
@@ -57,6 +58,37 @@ func TestAccOpensearchOpenDistroISMPolicyMapping(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchISMPolicyMapping(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, "opensearch_ism_policy", "/_plugins/_ism/policies/{id}"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOpensearchOpenDistroISMPolicyMapping,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"opensearch_ism_policy_mapping.test_mapping",
+						"policy_id",
+						"test_policy",
+					),
+				),
+			},
+			{
+				Config: testAccOpensearchOpenDistroISMPolicyMappingUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"opensearch_ism_policy_mapping.test_mapping",
+						"policy_id",
+						"test_policy",
+					),
+				),
+			},
+		},
+	})
+}
+
 func testCheckOpensearchISMPolicyMappingExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchISMPolicy(t *testing.T) {
@@ -44,6 +45,22 @@ func TestAccOpensearchISMPolicy(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchISMPolicy(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_ism_policy.test_policy", "/_plugins/_ism/policies/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchISMPolicyV7,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"opensearch_ism_policy.test_policy",
+					"policy_id",
+					"test_policy",
+				),
+			),
+		},
+	))
+}
+
 func testCheckOpensearchISMPolicyExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
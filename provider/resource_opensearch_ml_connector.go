@@ -3,9 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,6 +17,7 @@ func resourceOpensearchMLConnector() *schema.Resource {
 		ReadContext:   resourceOpensearchMLConnectorRead,
 		UpdateContext: resourceOpensearchMLConnectorUpdate,
 		DeleteContext: resourceOpensearchMLConnectorDelete,
+		CustomizeDiff: requireCapabilities(capabilityMLConnectors, nil),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -226,8 +225,7 @@ func resourceOpensearchMLConnectorRead(ctx context.Context, d *schema.ResourceDa
 	url := conf.rawUrl + fmt.Sprintf("/_plugins/_ml/connectors/%s", d.Id())
 	connector, err := performRequestAndParse(ctx, conf.osClient, "GET", url, nil, "read ML Connector")
 	if err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -377,9 +375,8 @@ func resourceOpensearchMLConnectorDelete(ctx context.Context, d *schema.Resource
 	url := conf.rawUrl + fmt.Sprintf("/_plugins/_ml/connectors/%s", d.Id())
 	_, err := performRequestAndParse(ctx, conf.osClient, "DELETE", url, nil, "delete ML Connector")
 	if err != nil {
-		var httpErr *HTTPError
 		// Ignore 404 errors - resource is already deleted
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(err)
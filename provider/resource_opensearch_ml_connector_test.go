@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 const (
@@ -130,6 +131,28 @@ func TestAccOpensearchMLConnector_Minimal_HTTP(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchMLConnector(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_ml_connector.minimal_http", "/_plugins/_ml/connectors/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchMLConnectorConfig_Minimal_HTTP(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_ml_connector.minimal_http", "name", "minimal_http"),
+				resource.TestCheckResourceAttr("opensearch_ml_connector.minimal_http", "protocol", "http"),
+				resource.TestCheckResourceAttr("opensearch_ml_connector.minimal_http", "credential.openAIKey", "sk-test123456789"),
+				resource.TestCheckResourceAttr("opensearch_ml_connector.minimal_http", "actions.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_ml_connector.minimal_http", "actions.0.action_type", "predict"),
+			),
+		},
+		resource.TestStep{
+			ResourceName:            "opensearch_ml_connector.minimal_http",
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateVerifyIgnore: []string{"credential"},
+		},
+	))
+}
+
 func TestAccOpensearchMLConnector_WithPrePostProcessing(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -37,6 +37,9 @@ func resourceOpensearchMLModel() *schema.Resource {
 		ReadContext:   resourceOpensearchMLModelRead,
 		UpdateContext: resourceOpensearchMLModelUpdate,
 		DeleteContext: resourceOpensearchMLModelDelete,
+		CustomizeDiff: requireCapabilities(capabilityMLModels, map[string]capability{
+			"guardrails": capabilityMLGuardrails,
+		}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -543,8 +546,7 @@ func resourceOpensearchMLModelRead(ctx context.Context, d *schema.ResourceData,
 
 	model, err := getMLModelFromAPI(ctx, conf, d.Id())
 	if err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -790,9 +792,8 @@ func resourceOpensearchMLModelDelete(ctx context.Context, d *schema.ResourceData
 	url := conf.rawUrl + fmt.Sprintf("/_plugins/_ml/models/%s", d.Id())
 	_, err := performRequestAndParse(ctx, conf.osClient, "DELETE", url, nil, "delete ML Model")
 	if err != nil {
-		var httpErr *HTTPError
 		// Ignore 404 errors - resource is already deleted
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(err)
@@ -977,8 +978,7 @@ func undeployMLModel(ctx context.Context, conf *ProviderConf, modelID string) er
 	_, err := performRequestAndParse(ctx, conf.osClient, "POST", conf.rawUrl+fmt.Sprintf("/_plugins/_ml/models/%s/_undeploy", modelID), nil, "undeploy ML Model")
 	if err != nil {
 		// Ignore errors if model is not found or not deployed (already in desired state).
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			return nil
 		}
 		if strings.Contains(err.Error(), "not deployed") ||
@@ -1050,8 +1050,7 @@ func waitForModelDeploymentStateChange(ctx context.Context, client *opensearch.C
 
 		result, err := performRequestAndParse(ctx, client, "GET", url, nil, "get ML Model status")
 		if err != nil {
-			var httpErr *HTTPError
-			if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			if isNotFound(err) {
 				return "UNDEPLOYED", nil
 			}
 			return "", err
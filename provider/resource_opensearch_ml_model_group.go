@@ -3,9 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,6 +17,7 @@ func resourceOpensearchMLModelGroup() *schema.Resource {
 		ReadContext:   resourceOpensearchMLModelGroupRead,
 		UpdateContext: resourceOpensearchMLModelGroupUpdate,
 		DeleteContext: resourceOpensearchMLModelGroupDelete,
+		CustomizeDiff: requireCapabilities(capabilityMLModelGroups, nil),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -95,8 +94,7 @@ func resourceOpensearchMLModelGroupRead(ctx context.Context, d *schema.ResourceD
 
 	modelGroup, err := getMLModelGroupFromAPI(ctx, conf, d.Id())
 	if err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -161,9 +159,8 @@ func resourceOpensearchMLModelGroupDelete(ctx context.Context, d *schema.Resourc
 	url := conf.rawUrl + fmt.Sprintf("/_plugins/_ml/model_groups/%s", d.Id())
 	_, err := performRequestAndParse(ctx, conf.osClient, "DELETE", url, nil, "delete ML Model Group")
 	if err != nil {
-		var httpErr *HTTPError
 		// Ignore 404 errors - resource is already deleted
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		if isNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(err)
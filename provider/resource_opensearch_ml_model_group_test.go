@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 // Map of resource IDS to verify resources are not recreated on updates.
@@ -72,6 +73,25 @@ func TestAccOpensearchMLModelGroup_Minimal(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchMLModelGroup(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_ml_model_group.minimal", "/_plugins/_ml/model_groups/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchMLModelGroupConfig_Minimal(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_ml_model_group.minimal", "name", "minimal"),
+				resource.TestCheckResourceAttr("opensearch_ml_model_group.minimal", "access_mode", "private"),
+				resource.TestCheckResourceAttr("opensearch_ml_model_group.minimal", "backend_roles.#", "0"),
+			),
+		},
+		resource.TestStep{
+			ResourceName:      "opensearch_ml_model_group.minimal",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	))
+}
+
 func TestAccOpensearchMLModelGroup_WithOptionalAttributes(t *testing.T) {
 	defer func() {
 		delete(savedMLModelGroupIDs, "opensearch_ml_model_group.with_optional")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 const (
@@ -285,6 +286,22 @@ func TestAccOpensearchMLModel_ThirdParty(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchMLModel(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_ml_model.thirdparty_minimal", "/_plugins/_ml/models/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchMLModelConfig_ThirdParty_Minimal(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_ml_model.thirdparty_minimal", "name", "thirdparty_minimal"),
+				resource.TestCheckResourceAttr("opensearch_ml_model.thirdparty_minimal", "function_name", "REMOTE"),
+				resource.TestCheckResourceAttrSet("opensearch_ml_model.thirdparty_minimal", "model_group_id"),
+				resource.TestCheckResourceAttrSet("opensearch_ml_model.thirdparty_minimal", "connector_id"),
+				resource.TestCheckResourceAttr("opensearch_ml_model.thirdparty_minimal", "is_enabled", "true"),
+			),
+		},
+	))
+}
+
 func TestAccOpensearchMLModel_GuardrailsModelType(t *testing.T) {
 	defer func() {
 		delete(savedMLModelIDs, "opensearch_ml_model.guardrails_model_type")
@@ -26,16 +26,29 @@ var openDistroMonitorSchema = map[string]*schema.Schema{
 		},
 		ValidateFunc: validation.StringIsJSON,
 	},
+	"primary_term": {
+		Description: "The primary term of the monitor version.",
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+	},
+	"seq_no": {
+		Description: "The sequence number of the monitor version.",
+		Type:        schema.TypeInt,
+		Optional:    true,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchMonitor() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch monitor. Please refer to the OpenSearch monitor documentation for details.",
-		Create:      resourceOpensearchOpenDistroMonitorCreate,
-		Read:        resourceOpensearchOpenDistroMonitorRead,
-		Update:      resourceOpensearchOpenDistroMonitorUpdate,
-		Delete:      resourceOpensearchOpenDistroMonitorDelete,
-		Schema:      openDistroMonitorSchema,
+		Description:   "Provides an OpenSearch monitor. Please refer to the OpenSearch monitor documentation for details.",
+		Create:        resourceOpensearchOpenDistroMonitorCreate,
+		Read:          resourceOpensearchOpenDistroMonitorRead,
+		Update:        resourceOpensearchOpenDistroMonitorUpdate,
+		Delete:        resourceOpensearchOpenDistroMonitorDelete,
+		CustomizeDiff: requireCapabilities(capabilityAlerting, nil),
+		Schema:        openDistroMonitorSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -62,7 +75,7 @@ func resourceOpensearchOpenDistroMonitorCreate(d *schema.ResourceData, m interfa
 func resourceOpensearchOpenDistroMonitorRead(d *schema.ResourceData, m interface{}) error {
 	res, err := resourceOpensearchOpenDistroGetMonitor(d.Id(), m)
 
-	if elastic7.IsNotFound(err) {
+	if isNotFound(err) {
 		log.Printf("[WARN] Monitor (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -82,6 +95,12 @@ func resourceOpensearchOpenDistroMonitorRead(d *schema.ResourceData, m interface
 	if err != nil {
 		return err
 	}
+	if err := d.Set("primary_term", res.PrimaryTerm); err != nil {
+		return fmt.Errorf("error setting primary_term: %s", err)
+	}
+	if err := d.Set("seq_no", res.SeqNo); err != nil {
+		return fmt.Errorf("error setting seq_no: %s", err)
+	}
 	err = d.Set("body", monitorJsonNormalized)
 	return err
 }
@@ -207,10 +226,11 @@ func resourceOpensearchOpenDistroPutMonitor(d *schema.ResourceData, m interface{
 	res, err = osClient.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 		Method: "PUT",
 		Path:   path,
+		Params: versionParams(d),
 		Body:   monitorJSON,
 	})
 	if err != nil {
-		return response, err
+		return response, versionConflictError("monitor", d.Id(), err)
 	}
 	body = res.Body
 
@@ -222,8 +242,10 @@ func resourceOpensearchOpenDistroPutMonitor(d *schema.ResourceData, m interface{
 }
 
 type monitorResponse struct {
-	Version int                    `json:"_version"`
-	ID      string                 `json:"_id"`
-	Name    string                 `json:"name"`
-	Monitor map[string]interface{} `json:"monitor"`
+	Version     int                    `json:"_version"`
+	ID          string                 `json:"_id"`
+	PrimaryTerm int                    `json:"_primary_term"`
+	SeqNo       int                    `json:"_seq_no"`
+	Name        string                 `json:"name"`
+	Monitor     map[string]interface{} `json:"monitor"`
 }
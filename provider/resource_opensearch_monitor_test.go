@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroMonitor(t *testing.T) {
@@ -27,6 +28,15 @@ func TestAccOpensearchOpenDistroMonitor(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchMonitor(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_monitor.test_monitor", "/_plugins/_alerting/monitors/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchOpenDistroMonitor,
+		},
+	))
+}
+
 func testCheckOpensearchMonitorExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
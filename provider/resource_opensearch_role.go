@@ -111,16 +111,22 @@ var openDistroRoleSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
 	},
+	"etag": {
+		Description: "Hash of the role as last read, compared with the role on the cluster before an update to detect changes made since the last refresh.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchRole() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch security role resource. Please refer to the OpenSearch Access Control documentation for details.",
-		Create:      resourceOpensearchOpenDistroRoleCreate,
-		Read:        resourceOpensearchOpenDistroRoleRead,
-		Update:      resourceOpensearchOpenDistroRoleUpdate,
-		Delete:      resourceOpensearchOpenDistroRoleDelete,
-		Schema:      openDistroRoleSchema,
+		Description:   "Provides an OpenSearch security role resource. Please refer to the OpenSearch Access Control documentation for details.",
+		Create:        resourceOpensearchOpenDistroRoleCreate,
+		Read:          resourceOpensearchOpenDistroRoleRead,
+		Update:        resourceOpensearchOpenDistroRoleUpdate,
+		Delete:        resourceOpensearchOpenDistroRoleDelete,
+		CustomizeDiff: requireCapabilities(capabilitySecurityRoles, nil),
+		Schema:        openDistroRoleSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -142,7 +148,7 @@ func resourceOpensearchOpenDistroRoleRead(d *schema.ResourceData, m interface{})
 	res, err := resourceOpensearchGetOpenDistroRole(d.Id(), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpenDistroRole (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -165,11 +171,23 @@ func resourceOpensearchOpenDistroRoleRead(d *schema.ResourceData, m interface{})
 	if err := d.Set("description", res.Description); err != nil {
 		return fmt.Errorf("error setting description: %s", err)
 	}
+	etag, err := objectETag(res)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("etag", etag); err != nil {
+		return fmt.Errorf("error setting etag: %s", err)
+	}
 
 	return nil
 }
 
 func resourceOpensearchOpenDistroRoleUpdate(d *schema.ResourceData, m interface{}) error {
+	current, err := resourceOpensearchGetOpenDistroRole(d.Id(), m)
+	if err := checkETag(d, "role", current, err); err != nil {
+		return err
+	}
+
 	if _, err := resourceOpensearchPutOpenDistroRole(d, m); err != nil {
 		return err
 	}
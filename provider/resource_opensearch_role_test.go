@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroRole(t *testing.T) {
@@ -101,6 +102,35 @@ func TestAccOpensearchOpenDistroRole(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchRole(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_role.test", "/_plugins/_security/api/roles/{id}",
+		resource.TestStep{
+			Config: testAccOpenDistroRoleResource(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_role.test", "id", randomName),
+				resource.TestCheckResourceAttr("opensearch_role.test", "cluster_permissions.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_role.test", "tenant_permissions.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_role.test", "index_permissions.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_role.test", "description", "test"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroRoleResourceUpdated(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_role.test", "tenant_permissions.#", "2"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroRoleResourceWithoutTenantPermissions(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_role.test", "tenant_permissions.#", "0"),
+			),
+		},
+	))
+}
+
 func TestAccOpensearchOpenDistroRole_importBasic(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
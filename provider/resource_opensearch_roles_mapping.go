@@ -50,15 +50,21 @@ var openDistroRolesMappingSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Elem:        &schema.Schema{Type: schema.TypeString},
 	},
+	"etag": {
+		Description: "Hash of the role mapping as last read, compared with the role mapping on the cluster before an update to detect changes made since the last refresh.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchRolesMapping() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOpensearchOpenDistroRolesMappingCreate,
-		Read:   resourceOpensearchOpenDistroRolesMappingRead,
-		Update: resourceOpensearchOpenDistroRolesMappingUpdate,
-		Delete: resourceOpensearchOpenDistroRolesMappingDelete,
-		Schema: openDistroRolesMappingSchema,
+		Create:        resourceOpensearchOpenDistroRolesMappingCreate,
+		Read:          resourceOpensearchOpenDistroRolesMappingRead,
+		Update:        resourceOpensearchOpenDistroRolesMappingUpdate,
+		Delete:        resourceOpensearchOpenDistroRolesMappingDelete,
+		CustomizeDiff: requireCapabilities(capabilitySecurityMappings, nil),
+		Schema:        openDistroRolesMappingSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -81,7 +87,7 @@ func resourceOpensearchOpenDistroRolesMappingRead(d *schema.ResourceData, m inte
 	res, err := resourceOpensearchGetOpenDistroRolesMapping(d.Id(), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpenDistroRolesMapping (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -107,11 +113,23 @@ func resourceOpensearchOpenDistroRolesMappingRead(d *schema.ResourceData, m inte
 	if err := d.Set("and_backend_roles", res.AndBackendRoles); err != nil {
 		return fmt.Errorf("error setting and_backend_roles: %s", err)
 	}
+	etag, err := objectETag(res)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("etag", etag); err != nil {
+		return fmt.Errorf("error setting etag: %s", err)
+	}
 
 	return nil
 }
 
 func resourceOpensearchOpenDistroRolesMappingUpdate(d *schema.ResourceData, m interface{}) error {
+	current, err := resourceOpensearchGetOpenDistroRolesMapping(d.Id(), m)
+	if err := checkETag(d, "role mapping", current, err); err != nil {
+		return err
+	}
+
 	if _, err := resourceOpensearchPutOpenDistroRolesMapping(d, m); err != nil {
 		return err
 	}
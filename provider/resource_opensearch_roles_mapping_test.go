@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroRolesMapping(t *testing.T) {
@@ -83,6 +84,33 @@ func TestAccOpensearchOpenDistroRolesMapping(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchRolesMapping(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_roles_mapping.test", "/_plugins/_security/api/rolesmapping/{id}",
+		resource.TestStep{
+			Config: testAccOpenDistroRoleMappingTestOnlyUser(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_roles_mapping.test", "id", "readall"),
+				resource.TestCheckResourceAttr("opensearch_roles_mapping.test", "users.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_roles_mapping.test", "description", randomName),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroRolesMappingResource(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_roles_mapping.test", "backend_roles.#", "1"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroRoleMappingResourceUpdated(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_roles_mapping.test", "backend_roles.#", "2"),
+			),
+		},
+	))
+}
+
 func testAccCheckOpensearchRolesMappingDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "opensearch_roles_mappings_mapping" {
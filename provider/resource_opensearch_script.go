@@ -72,7 +72,7 @@ func resourceOpensearchScriptCreate(d *schema.ResourceData, m interface{}) error
 	if err == nil {
 		log.Printf("[INFO] script exists: %+v", err)
 		return fmt.Errorf("script already exists with ID: %v", scriptID)
-	} else if err != nil && !elastic7.IsNotFound(err) {
+	} else if err != nil && !isNotFound(err) {
 		return err
 	}
 
@@ -92,7 +92,7 @@ func resourceOpensearchScriptCreate(d *schema.ResourceData, m interface{}) error
 func resourceOpensearchScriptRead(d *schema.ResourceData, m interface{}) error {
 	scriptBody, err := resourceOpensearchGetScript(d.Id(), m)
 
-	if elastic7.IsNotFound(err) {
+	if isNotFound(err) {
 		log.Printf("[WARN] Script (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchScript(t *testing.T) {
@@ -27,6 +28,15 @@ func TestAccOpensearchScript(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchScript(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_script.test_script", "/_scripts/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchScript,
+		},
+	))
+}
+
 func testCheckOpensearchScriptExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -6,9 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"net/url"
 	"reflect"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,12 +49,13 @@ var openSearchSMPolicySchema = map[string]*schema.Schema{
 
 func resourceOpenSearchSMPolicy() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch Snapshot Management (SM) policy. Please refer to the OpenSearch SM documentation for details.",
-		Create:      resourceOpensearchSMPolicyCreate,
-		Read:        resourceOpensearchSMPolicyRead,
-		Update:      resourceOpensearchSMPolicyUpdate,
-		Delete:      resourceOpensearchSMPolicyDelete,
-		Schema:      openSearchSMPolicySchema,
+		Description:   "Provides an OpenSearch Snapshot Management (SM) policy. Please refer to the OpenSearch SM documentation for details.",
+		Create:        resourceOpensearchSMPolicyCreate,
+		Read:          resourceOpensearchSMPolicyRead,
+		Update:        resourceOpensearchSMPolicyUpdate,
+		Delete:        resourceOpensearchSMPolicyDelete,
+		CustomizeDiff: requireCapabilities(capabilitySnapshotManagement, nil),
+		Schema:        openSearchSMPolicySchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				var err = d.Set("policy_name", d.Id())
@@ -87,7 +86,7 @@ func resourceOpensearchSMPolicyRead(d *schema.ResourceData, m interface{}) error
 	policyResponse, err := resourceOpensearchGetSMPolicy(d.Get("policy_name").(string), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OpenSearch Policy (%s) not found, removing from state", d.Get("policy_name").(string))
 			d.SetId("")
 			return nil
@@ -148,7 +147,7 @@ func resourceOpensearchSMPolicyDelete(d *schema.ResourceData, m interface{}) err
 	})
 
 	if err != nil {
-		return fmt.Errorf("error deleting policy: %+v : %+v", path, err)
+		return fmt.Errorf("error deleting policy: %+v : %w", path, err)
 	}
 
 	return err
@@ -178,7 +177,7 @@ func resourceOpensearchGetSMPolicy(policyName string, m interface{}) (SMPolicyRe
 	})
 
 	if err != nil {
-		return *response, fmt.Errorf("error getting policy: %+v : %+v", path, err)
+		return *response, fmt.Errorf("error getting policy: %+v : %w", path, err)
 	}
 	body = &res.Body
 
@@ -198,14 +197,6 @@ func resourceOpensearchGetSMPolicy(policyName string, m interface{}) (SMPolicyRe
 func resourceOpensearchPostPutSMPolicy(d *schema.ResourceData, m interface{}, method string) (*SMPolicyResponse, error) {
 	response := new(SMPolicyResponse)
 	policyJSON := d.Get("body").(string)
-	seq := d.Get("seq_no").(int)
-	primTerm := d.Get("primary_term").(int)
-	params := url.Values{}
-
-	if seq >= 0 && primTerm > 0 {
-		params.Set("if_seq_no", strconv.Itoa(seq))
-		params.Set("if_primary_term", strconv.Itoa(primTerm))
-	}
 
 	path, err := uritemplates.Expand("/_plugins/_sm/policies/{policy_name}", map[string]string{
 		"policy_name": d.Get("policy_name").(string),
@@ -219,19 +210,21 @@ func resourceOpensearchPostPutSMPolicy(d *schema.ResourceData, m interface{}, me
 	if err != nil {
 		return nil, err
 	}
+	params := versionParams(d)
 	var res *elastic7.Response
 	res, err = osclient.PerformRequest(context.TODO(), elastic7.PerformRequestOptions{
 		Method:           method,
 		Path:             path,
 		Params:           params,
 		Body:             string(policyJSON),
-		RetryStatusCodes: []int{http.StatusConflict},
+		RetryStatusCodes: conflictRetryStatusCodes(params),
 		Retrier: elastic7.NewBackoffRetrier(
 			elastic7.NewExponentialBackoff(100*time.Millisecond, 30*time.Second),
 		),
 	})
 	if err != nil {
-		return response, fmt.Errorf("error posting policy: %+v : %+v : %+v", path, policyJSON, err)
+		err = fmt.Errorf("error posting policy: %+v : %+v : %w", path, policyJSON, err)
+		return response, versionConflictError("snapshot management policy", d.Get("policy_name").(string), err)
 	}
 	body = &res.Body
 
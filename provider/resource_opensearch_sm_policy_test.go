@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchSMPolicy(t *testing.T) {
@@ -45,6 +46,22 @@ func TestAccOpensearchSMPolicy(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchSMPolicy(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_sm_policy.test_policy", "/_plugins/_sm/policies/{policy_name}",
+		resource.TestStep{
+			Config: testAccOpensearchSMPolicyV7,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"opensearch_sm_policy.test_policy",
+					"policy_name",
+					"test_policy",
+				),
+			),
+		},
+	))
+}
+
 func testCheckOpensearchSMPolicyExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
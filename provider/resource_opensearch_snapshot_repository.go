@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	elastic7 "github.com/olivere/elastic/v7"
@@ -9,11 +10,12 @@ import (
 
 func resourceOpensearchSnapshotRepository() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch snapshot repository resource.",
-		Create:      resourceOpensearchSnapshotRepositoryCreate,
-		Read:        resourceOpensearchSnapshotRepositoryRead,
-		Update:      resourceOpensearchSnapshotRepositoryUpdate,
-		Delete:      resourceOpensearchSnapshotRepositoryDelete,
+		Description:   "Provides an OpenSearch snapshot repository resource.",
+		Create:        resourceOpensearchSnapshotRepositoryCreate,
+		Read:          resourceOpensearchSnapshotRepositoryRead,
+		Update:        resourceOpensearchSnapshotRepositoryUpdate,
+		Delete:        resourceOpensearchSnapshotRepositoryDelete,
+		CustomizeDiff: requireCapabilities(capabilitySnapshots, nil),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Description: "The name of the repository.",
@@ -60,6 +62,11 @@ func resourceOpensearchSnapshotRepositoryRead(d *schema.ResourceData, meta inter
 	repositoryType, settings, err = elastic7SnapshotGetRepository(osClient, id)
 
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Snapshot repository (%s) not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 
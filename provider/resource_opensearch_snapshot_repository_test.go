@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchSnapshotRepository(t *testing.T) {
@@ -25,6 +26,15 @@ func TestAccOpensearchSnapshotRepository(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchSnapshotRepository(t *testing.T) {
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_snapshot_repository.test", "/_snapshot/{id}",
+		resource.TestStep{
+			Config: testAccOpensearchSnapshotRepository,
+		},
+	))
+}
+
 func TestAccOpensearchSnapshotRepository_importBasic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -53,16 +53,22 @@ var openDistroUserSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
 	},
+	"etag": {
+		Description: "Hash of the user as last read, compared with the user on the cluster before an update to detect changes made since the last refresh.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	},
 }
 
 func resourceOpenSearchUser() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides an OpenSearch security user. Please refer to the OpenSearch Access Control documentation for details.",
-		Create:      resourceOpensearchOpenDistroUserCreate,
-		Read:        resourceOpensearchOpenDistroUserRead,
-		Update:      resourceOpensearchOpenDistroUserUpdate,
-		Delete:      resourceOpensearchOpenDistroUserDelete,
-		Schema:      openDistroUserSchema,
+		Description:   "Provides an OpenSearch security user. Please refer to the OpenSearch Access Control documentation for details.",
+		Create:        resourceOpensearchOpenDistroUserCreate,
+		Read:          resourceOpensearchOpenDistroUserRead,
+		Update:        resourceOpensearchOpenDistroUserUpdate,
+		Delete:        resourceOpensearchOpenDistroUserDelete,
+		CustomizeDiff: requireCapabilities(capabilitySecurityUsers, nil),
+		Schema:        openDistroUserSchema,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -85,7 +91,7 @@ func resourceOpensearchOpenDistroUserRead(d *schema.ResourceData, m interface{})
 	res, err := resourceOpensearchGetOpenDistroUser(d.Id(), m)
 
 	if err != nil {
-		if elastic7.IsNotFound(err) {
+		if isNotFound(err) {
 			log.Printf("[WARN] OdfeUser (%s) not found, removing from state", d.Id())
 			d.SetId("")
 			return nil
@@ -93,14 +99,25 @@ func resourceOpensearchOpenDistroUserRead(d *schema.ResourceData, m interface{})
 		return err
 	}
 
+	etag, err := objectETag(res)
+	if err != nil {
+		return err
+	}
+
 	ds := &resourceDataSetter{d: d}
 	ds.set("backend_roles", res.BackendRoles)
 	ds.set("attributes", res.Attributes)
 	ds.set("description", res.Description)
+	ds.set("etag", etag)
 	return ds.err
 }
 
 func resourceOpensearchOpenDistroUserUpdate(d *schema.ResourceData, m interface{}) error {
+	current, err := resourceOpensearchGetOpenDistroUser(d.Id(), m)
+	if err := checkETag(d, "user", current, err); err != nil {
+		return err
+	}
+
 	if _, err := resourceOpensearchPutOpenDistroUser(d, m); err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	elastic7 "github.com/olivere/elastic/v7"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 func TestAccOpensearchOpenDistroUser(t *testing.T) {
@@ -93,6 +94,31 @@ func TestAccOpensearchOpenDistroUser(t *testing.T) {
 	})
 }
 
+func TestUnitOpensearchUser(t *testing.T) {
+	randomName := "test" + acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	server := fakeopensearch.New(t)
+	resource.UnitTest(t, testUnitResourceCase(t, server, "opensearch_user.test", "/_plugins/_security/api/internalusers/{id}",
+		resource.TestStep{
+			Config: testAccOpenDistroUserResource(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_user.test", "id", randomName),
+				resource.TestCheckResourceAttr("opensearch_user.test", "backend_roles.#", "1"),
+				resource.TestCheckResourceAttr("opensearch_user.test", "attributes.some_attribute", "alpha"),
+				resource.TestCheckResourceAttr("opensearch_user.test", "description", "test"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroUserResourceUpdated(randomName),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("opensearch_user.test", "backend_roles.#", "2"),
+			),
+		},
+		resource.TestStep{
+			Config: testAccOpenDistroUserResourceMinimal(randomName),
+		},
+	))
+}
+
 func TestAccOpensearchOpenDistroUserMultiple(t *testing.T) {
 	provider := Provider()
 	diags := provider.Configure(context.Background(), &terraform.ResourceConfig{})
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
+)
+
+// testUnitResourceFunctions are resources created, updated and destroyed
+// against the fake cluster by calling their functions, without the Terraform
+// CLI. After each apply, the object at path must have the fields in sent,
+// dotted paths in the JSON body of a GET of path.
+var testUnitResourceFunctions = []struct {
+	resource string
+	path     string
+	// objects to create beforehand, pairs of path and JSON body
+	setup   [][2]string
+	config  map[string]interface{}
+	sent    map[string]interface{}
+	update  map[string]interface{}
+	updated map[string]interface{}
+}{
+	{
+		resource: "opensearch_script",
+		path:     "/_scripts/{id}",
+		config:   map[string]interface{}{"script_id": "my_script", "lang": "painless", "source": "return 1"},
+		sent:     map[string]interface{}{"script.lang": "painless", "script.source": "return 1"},
+		update:   map[string]interface{}{"script_id": "my_script", "lang": "painless", "source": "return 2"},
+		updated:  map[string]interface{}{"script.source": "return 2"},
+	},
+	{
+		resource: "opensearch_ingest_pipeline",
+		path:     "/_ingest/pipeline/{id}",
+		config:   map[string]interface{}{"name": "add-team", "body": `{"description":"team","processors":[{"set":{"field":"team","value":"search"}}]}`},
+		sent:     map[string]interface{}{"add-team.description": "team", "add-team.processors.0.set.value": "search"},
+		update:   map[string]interface{}{"name": "add-team", "body": `{"description":"team","processors":[{"set":{"field":"team","value":"ingest"}}]}`},
+		updated:  map[string]interface{}{"add-team.processors.0.set.value": "ingest"},
+	},
+	{
+		resource: "opensearch_component_template",
+		path:     "/_component_template/{id}",
+		config:   map[string]interface{}{"name": "shards", "body": `{"template":{"settings":{"index":{"number_of_shards":"1"}}}}`},
+		sent:     map[string]interface{}{"component_templates.0.component_template.template.settings.index.number_of_shards": "1"},
+		update:   map[string]interface{}{"name": "shards", "body": `{"template":{"settings":{"index":{"number_of_shards":"2"}}}}`},
+		updated:  map[string]interface{}{"component_templates.0.component_template.template.settings.index.number_of_shards": "2"},
+	},
+	{
+		resource: "opensearch_composable_index_template",
+		path:     "/_index_template/{id}",
+		config:   map[string]interface{}{"name": "logs", "body": `{"index_patterns":["logs-*"],"priority":1}`},
+		sent:     map[string]interface{}{"index_templates.0.index_template.index_patterns.0": "logs-*", "index_templates.0.index_template.priority": 1.0},
+		update:   map[string]interface{}{"name": "logs", "body": `{"index_patterns":["logs-*"],"priority":2}`},
+		updated:  map[string]interface{}{"index_templates.0.index_template.priority": 2.0},
+	},
+	{
+		resource: "opensearch_snapshot_repository",
+		path:     "/_snapshot/{id}",
+		config:   map[string]interface{}{"name": "backups", "type": "fs", "settings": map[string]interface{}{"location": "/tmp/backups"}},
+		sent:     map[string]interface{}{"backups.type": "fs", "backups.settings.location": "/tmp/backups"},
+		update:   map[string]interface{}{"name": "backups", "type": "fs", "settings": map[string]interface{}{"location": "/tmp/snapshots"}},
+		updated:  map[string]interface{}{"backups.settings.location": "/tmp/snapshots"},
+	},
+	{
+		resource: "opensearch_index",
+		path:     "/{id}/_settings",
+		config:   map[string]interface{}{"name": "logs-app", "number_of_shards": "1", "number_of_replicas": "1"},
+		sent:     map[string]interface{}{"logs-app.settings.index.number_of_shards": "1", "logs-app.settings.index.number_of_replicas": "1"},
+		update:   map[string]interface{}{"name": "logs-app", "number_of_shards": "1", "number_of_replicas": "2"},
+		updated:  map[string]interface{}{"logs-app.settings.index.number_of_replicas": "2"},
+	},
+	{
+		resource: "opensearch_role",
+		path:     "/_plugins/_security/api/roles/{id}",
+		config:   map[string]interface{}{"role_name": "reader", "description": "test", "cluster_permissions": []interface{}{"cluster_monitor"}},
+		sent:     map[string]interface{}{"reader.description": "test", "reader.cluster_permissions.0": "cluster_monitor"},
+		update:   map[string]interface{}{"role_name": "reader", "description": "test", "cluster_permissions": []interface{}{"cluster_all"}},
+		updated:  map[string]interface{}{"reader.cluster_permissions.0": "cluster_all"},
+	},
+	{
+		resource: "opensearch_user",
+		path:     "/_plugins/_security/api/internalusers/{id}",
+		config:   map[string]interface{}{"username": "jdoe", "password": "passw0rd!Passw0rd", "backend_roles": []interface{}{"admin"}, "attributes": map[string]interface{}{"team": "search"}},
+		sent:     map[string]interface{}{"jdoe.backend_roles.0": "admin", "jdoe.attributes.team": "search"},
+		update:   map[string]interface{}{"username": "jdoe", "password": "passw0rd!Passw0rd", "backend_roles": []interface{}{"admin"}, "attributes": map[string]interface{}{"team": "ingest"}},
+		updated:  map[string]interface{}{"jdoe.attributes.team": "ingest"},
+	},
+	{
+		resource: "opensearch_roles_mapping",
+		path:     "/_plugins/_security/api/rolesmapping/{id}",
+		config:   map[string]interface{}{"role_name": "readall", "users": []interface{}{"jdoe"}, "description": "test"},
+		sent:     map[string]interface{}{"readall.users.0": "jdoe", "readall.description": "test"},
+		update:   map[string]interface{}{"role_name": "readall", "users": []interface{}{"jdoe"}, "backend_roles": []interface{}{"readers"}, "description": "test"},
+		updated:  map[string]interface{}{"readall.backend_roles.0": "readers"},
+	},
+	{
+		resource: "opensearch_dashboard_tenant",
+		path:     "/_plugins/_security/api/tenants/{id}",
+		config:   map[string]interface{}{"tenant_name": "search", "description": "test"},
+		sent:     map[string]interface{}{"search.description": "test"},
+		update:   map[string]interface{}{"tenant_name": "search", "description": "test2"},
+		updated:  map[string]interface{}{"search.description": "test2"},
+	},
+	{
+		resource: "opensearch_ism_policy",
+		path:     "/_plugins/_ism/policies/{id}",
+		config:   map[string]interface{}{"policy_id": "rollover", "body": `{"policy":{"description":"rollover","default_state":"hot","states":[{"name":"hot","actions":[],"transitions":[]}]}}`},
+		sent:     map[string]interface{}{"policy.description": "rollover", "policy.default_state": "hot"},
+		update:   map[string]interface{}{"policy_id": "rollover", "body": `{"policy":{"description":"rollover daily","default_state":"hot","states":[{"name":"hot","actions":[],"transitions":[]}]}}`},
+		updated:  map[string]interface{}{"policy.description": "rollover daily"},
+	},
+	{
+		resource: "opensearch_sm_policy",
+		path:     "/_plugins/_sm/policies/{policy_name}",
+		setup:    [][2]string{{"/_snapshot/backups", `{"type":"fs","settings":{"location":"/tmp/backups"}}`}},
+		config:   map[string]interface{}{"policy_name": "daily", "body": `{"description":"daily","creation":{"schedule":{"cron":{"expression":"0 0 * * *","timezone":"UTC"}}},"snapshot_config":{"repository":"backups"}}`},
+		sent:     map[string]interface{}{"sm_policy.description": "daily", "sm_policy.snapshot_config.repository": "backups"},
+		update:   map[string]interface{}{"policy_name": "daily", "body": `{"description":"nightly","creation":{"schedule":{"cron":{"expression":"0 0 * * *","timezone":"UTC"}}},"snapshot_config":{"repository":"backups"}}`},
+		updated:  map[string]interface{}{"sm_policy.description": "nightly"},
+	},
+	{
+		resource: "opensearch_monitor",
+		path:     "/_plugins/_alerting/monitors/{id}",
+		config:   map[string]interface{}{"body": `{"name":"errors","type":"monitor","monitor_type":"query_level_monitor","enabled":true,"schedule":{"period":{"interval":1,"unit":"MINUTES"}},"inputs":[],"triggers":[]}`},
+		sent:     map[string]interface{}{"monitor.name": "errors", "monitor.schedule.period.interval": 1.0},
+		update:   map[string]interface{}{"body": `{"name":"errors","type":"monitor","monitor_type":"query_level_monitor","enabled":true,"schedule":{"period":{"interval":5,"unit":"MINUTES"}},"inputs":[],"triggers":[]}`},
+		updated:  map[string]interface{}{"monitor.schedule.period.interval": 5.0},
+	},
+	{
+		resource: "opensearch_cluster_settings",
+		path:     "/_cluster/settings",
+		config:   map[string]interface{}{"action_auto_create_index": "logs-*"},
+		sent:     map[string]interface{}{"persistent.action.auto_create_index": "logs-*"},
+		update:   map[string]interface{}{"action_auto_create_index": "logs-*,metrics-*"},
+		updated:  map[string]interface{}{"persistent.action.auto_create_index": "logs-*,metrics-*"},
+	},
+}
+
+func TestUnitResourceFunctions(t *testing.T) {
+	for _, tc := range testUnitResourceFunctions {
+		t.Run(tc.resource, func(t *testing.T) {
+			server := fakeopensearch.New(t)
+			for _, object := range tc.setup {
+				testUnitRequest(t, server, http.MethodPut, object[0], object[1])
+			}
+			p := testUnitProvider(t, server, nil)
+
+			state, err := testUnitApply(t, p, tc.resource, nil, tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error creating: %v", err)
+			}
+			if state == nil || state.ID == "" {
+				t.Fatalf("expected an ID after the create")
+			}
+			testUnitCheckSent(t, server, tc.path, state, tc.sent)
+
+			refreshed, diags := p.ResourcesMap[tc.resource].RefreshWithoutUpgrade(t.Context(), state, p.Meta())
+			if diags.HasError() {
+				t.Fatalf("unexpected error reading: %v", diags)
+			}
+			if refreshed == nil || refreshed.ID != state.ID {
+				t.Fatalf("expected the read to find %q", state.ID)
+			}
+
+			state, err = testUnitApply(t, p, tc.resource, refreshed, tc.update)
+			if err != nil {
+				t.Fatalf("unexpected error updating: %v", err)
+			}
+			testUnitCheckSent(t, server, tc.path, state, tc.updated)
+
+			if err := testUnitDestroy(t, p, tc.resource, state); err != nil {
+				t.Fatalf("unexpected error destroying: %v", err)
+			}
+			if status, _ := testUnitGet(t, server, tc.path, state); tc.resource != "opensearch_cluster_settings" && status != http.StatusNotFound {
+				t.Errorf("expected the object to be deleted, got status %d", status)
+			}
+		})
+	}
+}
+
+// testUnitCheckSent checks that the object at path on server has the fields
+// of want, dotted paths in its JSON body.
+func testUnitCheckSent(t *testing.T, server *fakeopensearch.Server, path string, state *terraform.InstanceState, want map[string]interface{}) {
+	t.Helper()
+	status, body := testUnitGet(t, server, path, state)
+	if status != http.StatusOK {
+		t.Fatalf("expected the object at %s, got status %d", path, status)
+	}
+	for field, value := range want {
+		got, ok := testUnitField(body, field)
+		gotJSON, _ := json.Marshal(got)
+		wantJSON, _ := json.Marshal(value)
+		if !ok || string(gotJSON) != string(wantJSON) {
+			t.Errorf("expected %s to be %s, got %s in %v", field, wantJSON, gotJSON, body)
+		}
+	}
+}
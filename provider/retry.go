@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Status codes with which the cluster rejects a request before processing it.
+// Only these are retried for requests that are not idempotent, as a request
+// failing otherwise, e.g. with HTTP 504, may still have been applied.
+var rejectedStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusServiceUnavailable,
+}
+
+// OpenSearch error types that indicate the cluster is temporarily unable to
+// serve the request, e.g. while a cluster manager election is in progress.
+// They are retried whatever the status code of the response.
+var retryableErrorTypes = []string{
+	"cluster_block_exception",
+	"process_cluster_event_timeout_exception",
+}
+
+// retryConfig controls how requests failing with a transient cluster error
+// are retried.
+type retryConfig struct {
+	maxAttempts          int
+	baseBackoff          time.Duration
+	maxBackoff           time.Duration
+	retryableStatusCodes []int
+}
+
+func expandRetryConfig(raw []interface{}) (*retryConfig, error) {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil, nil
+	}
+	m := raw[0].(map[string]interface{})
+
+	baseBackoff, err := time.ParseDuration(m["base_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry base_backoff: %w", err)
+	}
+	maxBackoff, err := time.ParseDuration(m["max_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry max_backoff: %w", err)
+	}
+	if maxBackoff < baseBackoff {
+		return nil, fmt.Errorf("retry max_backoff (%s) must not be lower than base_backoff (%s)", maxBackoff, baseBackoff)
+	}
+
+	codes := defaultRetryableStatusCodes
+	if set := m["retryable_status_codes"].(*schema.Set); set.Len() > 0 {
+		codes = make([]int, 0, set.Len())
+		for _, c := range set.List() {
+			codes = append(codes, c.(int))
+		}
+	}
+
+	return &retryConfig{
+		maxAttempts:          m["max_attempts"].(int),
+		baseBackoff:          baseBackoff,
+		maxBackoff:           maxBackoff,
+		retryableStatusCodes: codes,
+	}, nil
+}
+
+// retryTransport retries requests that fail with a retryable status code or
+// OpenSearch error type, waiting with exponential backoff between attempts.
+// Requests that are not idempotent are only retried when the cluster rejected
+// them.
+// A Retry-After header sent by the server takes precedence over the computed
+// backoff, capped at maxBackoff.
+type retryTransport struct {
+	rt     http.RoundTripper
+	config *retryConfig
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body has to be buffered so that it can be sent again on retry.
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		res, err := t.rt.RoundTrip(attemptReq)
+		if err != nil || attempt >= t.config.maxAttempts || !t.shouldRetry(req, res) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		log.Printf("[WARN] %s %s returned HTTP %d, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, res.StatusCode, wait, attempt, t.config.maxAttempts)
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, res *http.Response) bool {
+	if !isIdempotent(req) {
+		return containsInt(t.config.retryableStatusCodes, res.StatusCode) && containsInt(rejectedStatusCodes, res.StatusCode)
+	}
+	if containsInt(t.config.retryableStatusCodes, res.StatusCode) {
+		return true
+	}
+	if res.StatusCode < 400 {
+		return false
+	}
+
+	// Peek at the error type, leaving the body readable for the caller.
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return hasRetryableErrorType(body)
+}
+
+// isIdempotent returns whether sending req several times has the same effect
+// as sending it once, which isn't the case of a POST or PATCH changing the
+// cluster, e.g. indexing a document with a generated ID.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return !isMutatingRequest(req)
+	}
+	return true
+}
+
+func hasRetryableErrorType(body []byte) bool {
+	var parsed struct {
+		Error struct {
+			Type      string `json:"type"`
+			RootCause []struct {
+				Type string `json:"type"`
+			} `json:"root_cause"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return false
+	}
+
+	if containsString(retryableErrorTypes, parsed.Error.Type) {
+		return true
+	}
+	for _, cause := range parsed.Error.RootCause {
+		if containsString(retryableErrorTypes, cause.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. Without a
+// Retry-After header it is base_backoff * 2^(attempt-1) with jitter, so that
+// concurrent requests rejected together don't retry in lockstep.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if wait, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+		return min(wait, t.config.maxBackoff)
+	}
+
+	wait := t.config.baseBackoff
+	for i := 1; i < attempt && wait < t.config.maxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, t.config.maxBackoff)
+	if wait <= 0 {
+		return 0
+	}
+
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+func containsInt(h []int, n int) bool {
+	for _, e := range h {
+		if e == n {
+			return true
+		}
+	}
+	return false
+}
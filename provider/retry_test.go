@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakeFlakyServer answers each request with the next response from a scripted
+// FIFO queue, and with 200 OK once the queue is exhausted. The body of every
+// request is recorded so tests can check that it is re-sent on retry.
+type fakeFlakyServer struct {
+	server    *httptest.Server
+	calls     atomic.Int32
+	responses []fakeFlakyResponse
+	bodies    []string
+}
+
+type fakeFlakyResponse struct {
+	statusCode int
+	retryAfter string
+	body       string
+}
+
+func newFakeFlakyServer(t *testing.T, responses []fakeFlakyResponse) *fakeFlakyServer {
+	t.Helper()
+	f := &fakeFlakyServer{responses: responses}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		f.bodies = append(f.bodies, string(raw))
+
+		idx := int(f.calls.Add(1) - 1)
+		resp := fakeFlakyResponse{statusCode: http.StatusOK, body: `{"acknowledged":true}`}
+		if idx < len(f.responses) {
+			resp = f.responses[idx]
+		}
+		if resp.retryAfter != "" {
+			w.Header().Set("Retry-After", resp.retryAfter)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.statusCode)
+		_, _ = w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func testRetryClient(maxAttempts int) *http.Client {
+	return &http.Client{Transport: &retryTransport{
+		rt: http.DefaultTransport,
+		config: &retryConfig{
+			maxAttempts:          maxAttempts,
+			baseBackoff:          time.Millisecond,
+			maxBackoff:           10 * time.Millisecond,
+			retryableStatusCodes: defaultRetryableStatusCodes,
+		},
+	}}
+}
+
+func TestRetryTransport_RetriesTransientStatusCodes(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusTooManyRequests, body: `{}`},
+		{statusCode: http.StatusServiceUnavailable, body: `{}`},
+	})
+
+	res, err := testRetryClient(3).Post(fake.server.URL+"/_plugins/_security/api/roles/test", "application/json", strings.NewReader(`{"cluster_permissions":[]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if got, want := fake.calls.Load(), int32(3); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+	for i, body := range fake.bodies {
+		if body != `{"cluster_permissions":[]}` {
+			t.Errorf("attempt %d was sent with body %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransport_ReturnsLastResponseWhenAttemptsExhausted(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusBadGateway, body: `{}`},
+		{statusCode: http.StatusBadGateway, body: `{}`},
+		{statusCode: http.StatusGatewayTimeout, body: `{"error":"timeout"}`},
+	})
+
+	res, err := testRetryClient(3).Get(fake.server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusGatewayTimeout)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if string(body) != `{"error":"timeout"}` {
+		t.Errorf("expected the last response body to be returned, got %q", body)
+	}
+	if got, want := fake.calls.Load(), int32(3); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusBadRequest, body: `{"error":{"type":"illegal_argument_exception","reason":"bad"}}`},
+	})
+
+	res, err := testRetryClient(3).Get(fake.server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusBadRequest)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if !strings.Contains(string(body), "illegal_argument_exception") {
+		t.Errorf("expected the response body to remain readable, got %q", body)
+	}
+	if got, want := fake.calls.Load(), int32(1); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_RetriesTransientErrorTypes(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusForbidden, body: `{"error":{"root_cause":[{"type":"cluster_block_exception","reason":"blocked by: [SERVICE_UNAVAILABLE/2/no cluster-manager]"}],"type":"cluster_block_exception"},"status":403}`},
+		{statusCode: http.StatusInternalServerError, body: `{"error":{"root_cause":[{"type":"process_cluster_event_timeout_exception"}],"type":"exception"},"status":500}`},
+	})
+
+	res, err := testRetryClient(3).Get(fake.server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("status: got %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if got, want := fake.calls.Load(), int32(3); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_DoesNotReplayNonIdempotentRequests(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		path     string
+		response fakeFlakyResponse
+		calls    int32
+	}{
+		{
+			name:     "POST on gateway timeout",
+			method:   http.MethodPost,
+			path:     "/my-index/_doc",
+			response: fakeFlakyResponse{statusCode: http.StatusGatewayTimeout, body: `{}`},
+			calls:    1,
+		},
+		{
+			name:     "POST on bad gateway",
+			method:   http.MethodPost,
+			path:     "/_bulk",
+			response: fakeFlakyResponse{statusCode: http.StatusBadGateway, body: `{}`},
+			calls:    1,
+		},
+		{
+			name:     "PATCH on cluster event timeout",
+			method:   http.MethodPatch,
+			path:     "/_plugins/_security/api/roles",
+			response: fakeFlakyResponse{statusCode: http.StatusInternalServerError, body: `{"error":{"type":"process_cluster_event_timeout_exception"},"status":500}`},
+			calls:    1,
+		},
+		{
+			name:     "POST on too many requests",
+			method:   http.MethodPost,
+			path:     "/my-index/_doc",
+			response: fakeFlakyResponse{statusCode: http.StatusTooManyRequests, body: `{}`},
+			calls:    2,
+		},
+		{
+			name:     "read-only POST on gateway timeout",
+			method:   http.MethodPost,
+			path:     "/my-index/_search",
+			response: fakeFlakyResponse{statusCode: http.StatusGatewayTimeout, body: `{}`},
+			calls:    2,
+		},
+		{
+			name:     "PUT on gateway timeout",
+			method:   http.MethodPut,
+			path:     "/_plugins/_security/api/roles/test",
+			response: fakeFlakyResponse{statusCode: http.StatusGatewayTimeout, body: `{}`},
+			calls:    2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeFlakyServer(t, []fakeFlakyResponse{tc.response})
+
+			req, _ := http.NewRequest(tc.method, fake.server.URL+tc.path, strings.NewReader(`{"message":"hello"}`))
+			res, err := testRetryClient(3).Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			if got := fake.calls.Load(); got != tc.calls {
+				t.Errorf("calls: got %d, want %d", got, tc.calls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusTooManyRequests, retryAfter: "1", body: `{}`},
+	})
+
+	client := testRetryClient(2)
+	client.Transport.(*retryTransport).config.maxBackoff = 5 * time.Second
+
+	start := time.Now()
+	res, err := client.Get(fake.server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer res.Body.Close()
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, only waited %s", elapsed)
+	}
+	if got, want := fake.calls.Load(), int32(2); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_StopsWhenContextIsCancelled(t *testing.T) {
+	fake := newFakeFlakyServer(t, []fakeFlakyResponse{
+		{statusCode: http.StatusServiceUnavailable, retryAfter: "60", body: `{}`},
+	})
+
+	client := testRetryClient(3)
+	client.Transport.(*retryTransport).config.maxBackoff = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, fake.server.URL, nil)
+
+	_, err := client.Do(req)
+	if err == nil {
+		t.Fatal("expected an error once the context is cancelled, got nil")
+	}
+	if got, want := fake.calls.Load(), int32(1); got != want {
+		t.Errorf("calls: got %d, want %d", got, want)
+	}
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	rt := &retryTransport{config: &retryConfig{baseBackoff: 100 * time.Millisecond, maxBackoff: time.Second}}
+	res := &http.Response{Header: http.Header{}}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		got := rt.backoff(attempt, res)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: backoff %s not within [%s, %s]", attempt, got, want/2, want)
+		}
+	}
+
+	res.Header.Set("Retry-After", "120")
+	if got := rt.backoff(1, res); got != time.Second {
+		t.Errorf("expected Retry-After to be capped at max_backoff, got %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "", wantOk: false},
+		{value: "3", want: 3 * time.Second, wantOk: true},
+		{value: "Mon, 01 Jan 2024 12:00:05 GMT", want: 5 * time.Second, wantOk: true},
+		{value: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0, wantOk: true},
+		{value: "soon", wantOk: false},
+	}
+
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.value, now)
+		if ok != tc.wantOk || got != tc.want {
+			t.Errorf("parseRetryAfter(%q): got (%s, %t), want (%s, %t)", tc.value, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
+
+func TestExpandRetryConfig(t *testing.T) {
+	raw := map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_attempts": 5,
+				"base_backoff": "1s",
+				"max_backoff":  "1m",
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+
+	conf, err := expandRetryConfig(d.Get("retry").([]interface{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.maxAttempts != 5 || conf.baseBackoff != time.Second || conf.maxBackoff != time.Minute {
+		t.Errorf("unexpected retry config: %+v", conf)
+	}
+	if len(conf.retryableStatusCodes) != len(defaultRetryableStatusCodes) {
+		t.Errorf("expected default retryable status codes, got %v", conf.retryableStatusCodes)
+	}
+
+	if conf, err := expandRetryConfig(nil); conf != nil || err != nil {
+		t.Errorf("expected no retry config when the block is absent, got %+v, %v", conf, err)
+	}
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Index settings managed by Amazon OpenSearch Serverless. Collections reject
+// them in requests and may report them in responses, given without the
+// `index.` prefix.
+var serverlessManagedIndexSettings = []string{
+	"number_of_shards",
+	"number_of_replicas",
+	"auto_expand_replicas",
+	"refresh_interval",
+	"routing_partition_size",
+	"number_of_routing_shards",
+	"routing.allocation.enable",
+	"routing.rebalance.enable",
+	"shard.check_on_startup",
+	"codec",
+	"search.idle.after",
+	"gc_deletes",
+	"max_refresh_listeners",
+}
+
+// isServerless returns whether the provider targets an Amazon OpenSearch
+// Serverless collection.
+func (conf *ProviderConf) isServerless() bool {
+	_, serverless := awsSigningRegion(conf)
+	return serverless
+}
+
+// unsupportedOnServerless returns a capability only missing on Amazon
+// OpenSearch Serverless collections.
+func unsupportedOnServerless(feature string) capability {
+	return capability{feature: feature, unsupportedOnServerless: true}
+}
+
+// serverlessIndexAttributes returns the capabilities of the opensearch_index
+// attributes for the settings managed by Amazon OpenSearch Serverless.
+func serverlessIndexAttributes() map[string]capability {
+	attributes := make(map[string]capability, len(serverlessManagedIndexSettings))
+	for _, key := range serverlessManagedIndexSettings {
+		schemaName := strings.ReplaceAll(key, ".", "_")
+		attributes[schemaName] = unsupportedOnServerless(fmt.Sprintf("`%s` of opensearch_index", schemaName))
+	}
+	return attributes
+}
+
+func isServerlessManagedIndexSetting(key string) bool {
+	return containsString(serverlessManagedIndexSettings, strings.TrimPrefix(key, "index."))
+}
+
+// stripServerlessManagedTemplateSettings removes the settings managed by
+// Amazon OpenSearch Serverless from a template body as returned by the
+// cluster, so they don't show up as a difference with the configuration. The
+// settings of legacy templates are at the top level, those of composable and
+// component templates under `template`.
+func stripServerlessManagedTemplateSettings(body string) (string, error) {
+	var tpl map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &tpl); err != nil {
+		return "", err
+	}
+
+	stripServerlessManagedSettings(tpl)
+	if innerTpl, ok := tpl["template"].(map[string]interface{}); ok {
+		stripServerlessManagedSettings(innerTpl)
+	}
+
+	stripped, err := json.Marshal(tpl)
+	if err != nil {
+		return "", err
+	}
+	return string(stripped), nil
+}
+
+func stripServerlessManagedSettings(tpl map[string]interface{}) {
+	settings, ok := tpl["settings"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	normalized := normalizedIndexSettings(settings)
+	for k := range normalized {
+		if isServerlessManagedIndexSetting(k) {
+			delete(normalized, k)
+		}
+	}
+
+	if len(normalized) == 0 {
+		delete(tpl, "settings")
+	} else {
+		tpl["settings"] = normalized
+	}
+}
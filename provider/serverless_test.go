@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func testServerlessConf() *ProviderConf {
+	parsedUrl, _ := url.Parse("https://abcdefghij0123456789.us-east-1.aoss.amazonaws.com")
+	return &ProviderConf{
+		rawUrl:          parsedUrl.String(),
+		parsedUrl:       parsedUrl,
+		signAWSRequests: true,
+	}
+}
+
+func TestCheckCapabilityOnServerless(t *testing.T) {
+	conf := testServerlessConf()
+	if !conf.isServerless() {
+		t.Fatalf("expected %s to be detected as a serverless collection", conf.rawUrl)
+	}
+
+	// No request is made: the collection URL isn't reachable in tests.
+	err := conf.checkCapability(context.Background(), capabilityISM)
+	if want := "opensearch_ism_policy is not supported on Amazon OpenSearch Serverless collections"; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+
+	attributes := serverlessIndexAttributes()
+	err = conf.checkCapability(context.Background(), attributes["number_of_shards"])
+	if want := "`number_of_shards` of opensearch_index is not supported on Amazon OpenSearch Serverless collections"; err == nil || err.Error() != want {
+		t.Errorf("expected error %q, got %v", want, err)
+	}
+	if _, ok := attributes["routing_allocation_enable"]; !ok {
+		t.Errorf("expected attributes to be named after the schema, got %v", attributes)
+	}
+
+	if err := conf.checkCapability(context.Background(), capabilityMLModels); err != nil {
+		t.Errorf("expected no version or plugin check on serverless collections, got %v", err)
+	}
+}
+
+func TestStripServerlessManagedTemplateSettings(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "composable template",
+			body: `{"index_patterns":["logs-*"],"template":{"settings":{"index":{"number_of_shards":"2","number_of_replicas":"0","knn":"true"}}}}`,
+			want: `{"index_patterns":["logs-*"],"template":{"settings":{"index.knn":"true"}}}`,
+		},
+		{
+			name: "legacy template",
+			body: `{"index_patterns":["logs-*"],"settings":{"index.refresh_interval":"1s","routing":{"allocation":{"enable":"all"}}}}`,
+			want: `{"index_patterns":["logs-*"]}`,
+		},
+		{
+			name: "no settings",
+			body: `{"template":{"mappings":{"properties":{}}}}`,
+			want: `{"template":{"mappings":{"properties":{}}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := stripServerlessManagedTemplateSettings(tc.body)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
@@ -1,9 +1,23 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/olivere/elastic/v7/uritemplates"
+	"github.com/opensearch-project/terraform-provider-opensearch/internal/fakeopensearch"
 )
 
 // Retrieves a resource ID from Terraform state by name or an error if not found or ID is empty.
@@ -17,3 +31,263 @@ func getResourceIDFromState(s *terraform.State, name string) (string, error) {
 	}
 	return rs.Primary.ID, nil
 }
+
+// testUnitPreCheck skips a unit test running Terraform against the fake
+// OpenSearch server if no Terraform CLI is available, unless
+// TF_ACC_TERRAFORM_VERSION asks the test framework to download one, as
+// `make unit` does.
+func testUnitPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("Unit tests of resources skipped unless the Terraform CLI is installed or TF_ACC_TERRAFORM_PATH is set")
+	}
+}
+
+// testUnitProviderFactories returns the factories of providers targeting
+// server, whatever the url of the configuration.
+func testUnitProviderFactories(server *fakeopensearch.Server) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"opensearch": func() (*schema.Provider, error) {
+			p := Provider()
+			configure := p.ConfigureContextFunc
+			p.ConfigureContextFunc = func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				if err := d.Set("url", server.URL); err != nil {
+					return nil, diag.FromErr(err)
+				}
+				return configure(c, d)
+			}
+			return p, nil
+		},
+	}
+}
+
+// testUnitStatus returns the HTTP status of a GET of the path of rs on
+// server. Path is a URI template expanded with the attributes of rs, e.g.
+// `/_scripts/{id}`.
+func testUnitStatus(server *fakeopensearch.Server, path string, rs *terraform.ResourceState) (int, error) {
+	vars := map[string]string{}
+	for _, name := range regexp.MustCompile(`{(\w+)}`).FindAllStringSubmatch(path, -1) {
+		vars[name[1]] = rs.Primary.Attributes[name[1]]
+	}
+	vars["id"] = rs.Primary.ID
+	path, err := uritemplates.Expand(path, vars)
+	if err != nil {
+		return 0, err
+	}
+	res, err := http.Get(server.URL + path)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	return res.StatusCode, nil
+}
+
+// testUnitCheckExists checks that the object of the resource name exists on
+// server, at the path described in testUnitStatus.
+func testUnitCheckExists(server *fakeopensearch.Server, name, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		status, err := testUnitStatus(server, path, rs)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("%s %q not found, got status %d", rs.Type, rs.Primary.ID, status)
+		}
+		return nil
+	}
+}
+
+// testUnitCheckDestroy checks that the objects of all the resources of
+// resourceType are gone from server, at the path described in testUnitStatus.
+func testUnitCheckDestroy(server *fakeopensearch.Server, resourceType, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			status, err := testUnitStatus(server, path, rs)
+			if err != nil {
+				return err
+			}
+			if status != http.StatusNotFound {
+				return fmt.Errorf("%s %q still exists, got status %d", resourceType, rs.Primary.ID, status)
+			}
+		}
+		return nil
+	}
+}
+
+// testUnitProvider returns a provider targeting server configured with
+// providerConfig, to call the functions of resources and data sources
+// without the Terraform CLI.
+func testUnitProvider(t *testing.T, server *fakeopensearch.Server, providerConfig map[string]interface{}) *schema.Provider {
+	t.Helper()
+
+	raw := map[string]interface{}{"url": server.URL, "healthcheck": false}
+	for k, v := range providerConfig {
+		raw[k] = v
+	}
+	p := Provider()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("unable to configure the provider: %v", diags)
+	}
+	return p
+}
+
+// testUnitResourceCase returns a test case applying steps in turn against
+// server, checking after each applied step that the object of resourceName
+// exists at path, and at the end that the objects of its type are gone. Path
+// is a URI template as described in testUnitStatus.
+func testUnitResourceCase(t *testing.T, server *fakeopensearch.Server, resourceName, path string, steps ...resource.TestStep) resource.TestCase {
+	for i, step := range steps {
+		if step.ImportState || step.PlanOnly || step.ExpectError != nil {
+			continue
+		}
+		exists := testUnitCheckExists(server, resourceName, path)
+		if step.Check != nil {
+			exists = resource.ComposeTestCheckFunc(exists, step.Check)
+		}
+		steps[i].Check = exists
+	}
+
+	return resource.TestCase{
+		PreCheck:          func() { testUnitPreCheck(t) },
+		ProviderFactories: testUnitProviderFactories(server),
+		CheckDestroy:      testUnitCheckDestroy(server, strings.SplitN(resourceName, ".", 2)[0], path),
+		Steps:             steps,
+	}
+}
+
+// testUnitApply plans config for the resource name of p from state, nil to
+// create it, and applies the plan, as Terraform would without the CLI. It
+// returns the new state.
+func testUnitApply(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, error) {
+	t.Helper()
+	ctx := context.Background()
+	r := p.ResourcesMap[name]
+
+	if diags := p.ValidateResource(name, terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		return nil, fmt.Errorf("invalid configuration: %v", diags)
+	}
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), p.Meta())
+	if err != nil {
+		return nil, err
+	}
+	if diff == nil || diff.Empty() {
+		return state, nil
+	}
+	state, diags := r.Apply(ctx, state, diff, p.Meta())
+	if diags.HasError() {
+		return nil, fmt.Errorf("%v", diags)
+	}
+	return state, nil
+}
+
+// testUnitDestroy destroys the resource name of p in state.
+func testUnitDestroy(t *testing.T, p *schema.Provider, name string, state *terraform.InstanceState) error {
+	t.Helper()
+	if _, diags := p.ResourcesMap[name].Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, p.Meta()); diags.HasError() {
+		return fmt.Errorf("%v", diags)
+	}
+	return nil
+}
+
+// testUnitGet returns the status and the JSON body of a GET of path on
+// server. Path is a URI template expanded with the attributes of state as in
+// testUnitStatus.
+func testUnitGet(t *testing.T, server *fakeopensearch.Server, path string, state *terraform.InstanceState) (int, interface{}) {
+	t.Helper()
+	vars := map[string]string{}
+	for k, v := range state.Attributes {
+		vars[k] = v
+	}
+	vars["id"] = state.ID
+	path, err := uritemplates.Expand(path, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	var body interface{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil && res.StatusCode == http.StatusOK {
+		t.Fatalf("invalid body of %s: %v", path, err)
+	}
+	return res.StatusCode, body
+}
+
+// testUnitField returns the field of a JSON document at the dotted path, e.g.
+// `roles.0.name`, and whether it exists. Keys may contain dots themselves, as
+// flat settings do.
+func testUnitField(doc interface{}, path string) (interface{}, bool) {
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		if field, ok := v[path]; ok {
+			return field, true
+		}
+		keys := strings.Split(path, ".")
+		for i := len(keys) - 1; i > 0; i-- {
+			if field, ok := v[strings.Join(keys[:i], ".")]; ok {
+				return testUnitField(field, strings.Join(keys[i:], "."))
+			}
+		}
+	case []interface{}:
+		key, rest, _ := strings.Cut(path, ".")
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		if rest == "" {
+			return v[i], true
+		}
+		return testUnitField(v[i], rest)
+	}
+	return nil, false
+}
+
+// testUnitReadDataSource validates config and reads the data source name with
+// it from server, without the Terraform CLI, with a provider configured with
+// providerConfig. It returns the attributes read.
+func testUnitReadDataSource(t *testing.T, server *fakeopensearch.Server, providerConfig map[string]interface{}, name string, config map[string]interface{}) (map[string]string, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	p := testUnitProvider(t, server, providerConfig)
+
+	if diags := p.ValidateDataSource(name, terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		return nil, diags
+	}
+	ds := p.DataSourcesMap[name]
+	d := schema.TestResourceDataRaw(t, ds.Schema, config)
+	if diags := ds.ReadContext(ctx, d, p.Meta()); diags.HasError() {
+		return nil, diags
+	}
+	return d.State().Attributes, nil
+}
+
+// testUnitRequest sends a request with the JSON body to server, e.g. to set
+// up the objects a data source reads.
+func testUnitRequest(t *testing.T, server *fakeopensearch.Server, method, path, body string) {
+	t.Helper()
+	req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		t.Fatalf("%s %s: got status %d", method, path, res.StatusCode)
+	}
+}
@@ -12,8 +12,11 @@ import (
 	"net/http"
 	"os"
 	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mitchellh/go-homedir"
@@ -486,17 +489,59 @@ func readPathOrContent(poc string) (string, bool, error) {
 	return poc, false, nil
 }
 
+// Validates that a string is a duration as accepted by time.ParseDuration,
+// e.g. "500ms" or "1m30s".
+func validateDuration(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a valid duration: %s", k, v, err)}
+	}
+	return nil, nil
+}
+
+// timeValueRegexp matches the time values of the OpenSearch API, e.g. `30s`.
+var timeValueRegexp = regexp.MustCompile(`^(\d+)(d|h|m|s|ms|micros|nanos)$`)
+
+var timeValueUnits = map[string]time.Duration{
+	"d":      24 * time.Hour,
+	"h":      time.Hour,
+	"m":      time.Minute,
+	"s":      time.Second,
+	"ms":     time.Millisecond,
+	"micros": time.Microsecond,
+	"nanos":  time.Nanosecond,
+}
+
+// parseTimeValue parses a time value of the OpenSearch API, e.g. `30s`.
+func parseTimeValue(v string) (time.Duration, error) {
+	m := timeValueRegexp.FindStringSubmatch(v)
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a valid time value", v)
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid time value: %w", v, err)
+	}
+	return time.Duration(n) * timeValueUnits[m[2]], nil
+}
+
 // ============================================
 // ===    HTTP Request Helper Functions     ===
 // ============================================
 
 // HTTPError represents a non-2xx response from the OpenSearch API. Callers can
 // use errors.As to inspect StatusCode and branch on specific status codes
-// (e.g. http.StatusNotFound) without resorting to string matching.
+// (e.g. http.StatusNotFound) without resorting to string matching, or
+// errors.Is with the classes of errors.go.
 type HTTPError struct {
 	Operation  string
 	StatusCode int
-	Message    string
+	// OpenSearch error type, e.g. resource_not_found_exception
+	Type    string
+	Message string
 }
 
 func (e *HTTPError) Error() string {
@@ -526,12 +571,13 @@ func performRequestAndParse(ctx context.Context, client *opensearch.Client, meth
 	}
 
 	if res.StatusCode >= 400 {
-		message := string(responseBody)
+		httpErr := &HTTPError{Operation: operation, StatusCode: res.StatusCode, Message: string(responseBody)}
 		var parsed map[string]interface{}
 		if json.Unmarshal(responseBody, &parsed) == nil {
-			message = extractErrorMessage(parsed)
+			httpErr.Message = extractErrorMessage(parsed)
+			httpErr.Type = extractErrorType(parsed)
 		}
-		return nil, &HTTPError{Operation: operation, StatusCode: res.StatusCode, Message: message}
+		return nil, translateError(httpErr)
 	}
 
 	var result map[string]interface{}
@@ -552,6 +598,17 @@ func extractErrorMessage(result map[string]interface{}) string {
 	return "unknown error"
 }
 
+// Extracts the type of an OpenSearch API error response, e.g.
+// index_not_found_exception.
+func extractErrorType(result map[string]interface{}) string {
+	if errDetail, ok := result["error"].(map[string]interface{}); ok {
+		if errorType, ok := errDetail["type"].(string); ok {
+			return errorType
+		}
+	}
+	return ""
+}
+
 // ============================================
 // ===  Diff Suppression Helper Functions   ===
 // ============================================